@@ -0,0 +1,168 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"lab1/experiment"
+)
+
+// Флаги командной строки. Заданные флаги переопределяют значения по
+// умолчанию и значения из файла конфигурации.
+type cliFlags struct {
+	pop       string
+	gens      string
+	crossProb string
+	mutProb   string
+	crossover string
+	elitism   string
+	out       string
+	csv       string
+	sqlite    string
+	plotDir   string
+	plots     string
+	autoTune  bool
+}
+
+func parseFlags() (cliFlags, string) {
+	var f cliFlags
+	flag.StringVar(&f.pop, "pop", "", "размеры популяции через запятую, например 50,100")
+	flag.StringVar(&f.gens, "gens", "", "числа поколений через запятую")
+	flag.StringVar(&f.crossProb, "pc", "", "вероятности скрещивания через запятую")
+	flag.StringVar(&f.mutProb, "pm", "", "вероятности мутации через запятую")
+	flag.StringVar(&f.crossover, "crossover", "", "типы скрещивания через запятую (onepoint, twopoint, uniform, arithmetic)")
+	flag.StringVar(&f.elitism, "elitism", "", "размеры элиты через запятую")
+	flag.StringVar(&f.out, "out", "", "файл результатов JSON")
+	flag.StringVar(&f.csv, "csv", "", "файл результатов CSV")
+	flag.StringVar(&f.sqlite, "sqlite", "", "файл результатов SQLite")
+	flag.BoolVar(&f.autoTune, "auto-workers", false, "подобрать число потоков пробным прогоном (переопределяет parallelism)")
+	flag.StringVar(&f.plotDir, "plot-dir", "", "каталог графиков")
+	flag.StringVar(&f.plots, "plots", "", "графики через запятую (имена файлов без расширения) или none; по умолчанию все")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Использование: %s [флаги] [config.json]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	return f, flag.Arg(0)
+}
+
+// Применяет явно заданные флаги к описанию эксперимента.
+func (f cliFlags) apply(options *experiment.RunnerOptions) error {
+	var err error
+	flag.Visit(func(fl *flag.Flag) {
+		if err != nil {
+			return
+		}
+		grid := &options.Grid
+		switch fl.Name {
+		case "pop":
+			grid.PopulationSizes, err = parseIntList(f.pop)
+		case "gens":
+			grid.MaxGenerations, err = parseIntList(f.gens)
+		case "pc":
+			grid.CrossoverProbs, err = parseFloatList(f.crossProb)
+		case "pm":
+			grid.MutationProbs, err = parseFloatList(f.mutProb)
+		case "crossover":
+			grid.CrossoverTypes, err = parseStringList(f.crossover)
+		case "elitism":
+			grid.ElitismCounts, err = parseIntList(f.elitism)
+		case "out":
+			options.ResultsFile = f.out
+		case "csv":
+			options.CSVFile = f.csv
+		case "sqlite":
+			options.SQLiteFile = f.sqlite
+		case "auto-workers":
+			options.AutoTuneWorkers = f.autoTune
+		case "plot-dir":
+			options.PlotDir = f.plotDir
+		}
+		if err != nil {
+			err = fmt.Errorf("-%s: %w", fl.Name, err)
+		}
+	})
+	return err
+}
+
+// Разбивает список через запятую; пробелы вокруг элементов игнорируются.
+// Пустой список и пустые элементы считаются ошибкой.
+func parseStringList(s string) ([]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, fmt.Errorf("пустой список")
+	}
+	items := strings.Split(s, ",")
+	for i, item := range items {
+		items[i] = strings.TrimSpace(item)
+		if items[i] == "" {
+			return nil, fmt.Errorf("пустой элемент %d в списке %q", i+1, s)
+		}
+	}
+	return items, nil
+}
+
+func parseIntList(s string) ([]int, error) {
+	items, err := parseStringList(s)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]int, len(items))
+	for i, item := range items {
+		if values[i], err = strconv.Atoi(item); err != nil {
+			return nil, fmt.Errorf("некорректное целое %q", item)
+		}
+	}
+	return values, nil
+}
+
+func parseFloatList(s string) ([]float64, error) {
+	items, err := parseStringList(s)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]float64, len(items))
+	for i, item := range items {
+		if values[i], err = strconv.ParseFloat(item, 64); err != nil {
+			return nil, fmt.Errorf("некорректное число %q", item)
+		}
+	}
+	return values, nil
+}
+
+// Оставляет графики с перечисленными именами (имя файла без расширения);
+// "none" отключает графики, пустая строка оставляет все.
+func selectPlots(jobs []plotJob, names string) ([]plotJob, error) {
+	if names == "" {
+		return jobs, nil
+	}
+	if strings.TrimSpace(names) == "none" {
+		return nil, nil
+	}
+
+	wanted, err := parseStringList(names)
+	if err != nil {
+		return nil, err
+	}
+	selected := make([]plotJob, 0, len(wanted))
+	for _, name := range wanted {
+		found := false
+		for _, job := range jobs {
+			if strings.TrimSuffix(job.file, ".png") == name {
+				selected = append(selected, job)
+				found = true
+				break
+			}
+		}
+		if !found {
+			available := make([]string, len(jobs))
+			for i, job := range jobs {
+				available[i] = strings.TrimSuffix(job.file, ".png")
+			}
+			return nil, fmt.Errorf("неизвестный график %q; доступны: %s", name, strings.Join(available, ", "))
+		}
+	}
+	return selected, nil
+}
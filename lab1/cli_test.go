@@ -0,0 +1,91 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseIntList(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    []int
+		wantErr bool
+	}{
+		{"пустая строка", "", nil, true},
+		{"только пробелы", "  ", nil, true},
+		{"одно значение", "50", []int{50}, false},
+		{"несколько с пробелами", " 50, 100 ,200", []int{50, 100, 200}, false},
+		{"отрицательное", "-1", []int{-1}, false},
+		{"пустой элемент", "50,,100", nil, true},
+		{"висячая запятая", "50,", nil, true},
+		{"не число", "50,abc", nil, true},
+		{"дробное", "1.5", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseIntList(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseIntList(%q) ошибка = %v, ожидалась ошибка: %v", tt.in, err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("parseIntList(%q) = %v, ожидалось %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFloatList(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    []float64
+		wantErr bool
+	}{
+		{"пустая строка", "", nil, true},
+		{"одно значение", "0.05", []float64{0.05}, false},
+		{"несколько", "0.6,0.8, 1", []float64{0.6, 0.8, 1}, false},
+		{"экспонента", "1e-3", []float64{0.001}, false},
+		{"пустой элемент", "0.6,,0.8", nil, true},
+		{"десятичная запятая", "0,5;0,8", nil, true},
+		{"не число", "x", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFloatList(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseFloatList(%q) ошибка = %v, ожидалась ошибка: %v", tt.in, err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("parseFloatList(%q) = %v, ожидалось %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseStringList(t *testing.T) {
+	if got, err := parseStringList(" onepoint , uniform"); err != nil || !slices.Equal(got, []string{"onepoint", "uniform"}) {
+		t.Fatalf("получено %v, %v; ожидалось [onepoint uniform]", got, err)
+	}
+	for _, in := range []string{"", " ", ",", "onepoint, "} {
+		if _, err := parseStringList(in); err == nil {
+			t.Errorf("parseStringList(%q): ожидалась ошибка", in)
+		}
+	}
+}
+
+func TestSelectPlots(t *testing.T) {
+	jobs := []plotJob{{file: "convergence.png"}, {file: "heatmap.png"}}
+	if got, err := selectPlots(jobs, ""); err != nil || len(got) != 2 {
+		t.Fatalf("без фильтра: %v, %v", got, err)
+	}
+	if got, err := selectPlots(jobs, "none"); err != nil || len(got) != 0 {
+		t.Fatalf("none: %v, %v", got, err)
+	}
+	if got, err := selectPlots(jobs, "heatmap"); err != nil || len(got) != 1 || got[0].file != "heatmap.png" {
+		t.Fatalf("heatmap: %v, %v", got, err)
+	}
+	if _, err := selectPlots(jobs, "missing"); err == nil {
+		t.Fatal("для неизвестного графика ожидалась ошибка")
+	}
+}
@@ -0,0 +1,124 @@
+package experiment
+
+import (
+	"math"
+	"sort"
+)
+
+// Ранговая корреляция Спирмена между каждым числовым параметром
+// конфигурации и относительной ошибкой по всем результатам ГА.
+// Для параметра без разброса значений корреляция не определена и не
+// включается в результат.
+func (ar *AllResults) ParameterCorrelations() map[string]float64 {
+	params := map[string]func(ExperimentConfig) float64{
+		"population_size": func(c ExperimentConfig) float64 { return float64(c.PopulationSize) },
+		"max_generations": func(c ExperimentConfig) float64 { return float64(c.MaxGenerations) },
+		"crossover_prob":  func(c ExperimentConfig) float64 { return c.CrossoverProb },
+		"mutation_prob":   func(c ExperimentConfig) float64 { return c.MutationProb },
+		"elitism_count":   func(c ExperimentConfig) float64 { return float64(c.ElitismCount) },
+	}
+
+	relErrors := make([]float64, len(ar.GAResults))
+	for i, r := range ar.GAResults {
+		relErrors[i] = r.RelativeError
+	}
+
+	correlations := make(map[string]float64)
+	for name, value := range params {
+		values := make([]float64, len(ar.GAResults))
+		for i, r := range ar.GAResults {
+			values[i] = value(r.Config)
+		}
+
+		rho := spearman(values, relErrors)
+		if !math.IsNaN(rho) {
+			correlations[name] = rho
+		}
+	}
+
+	return correlations
+}
+
+func spearman(x, y []float64) float64 {
+	if len(x) != len(y) || len(x) < 2 {
+		return math.NaN()
+	}
+	return pearson(ranks(x), ranks(y))
+}
+
+// Ранги с усреднением для одинаковых значений.
+func ranks(values []float64) []float64 {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return values[order[i]] < values[order[j]]
+	})
+
+	result := make([]float64, len(values))
+	for i := 0; i < len(order); {
+		j := i
+		for j+1 < len(order) && values[order[j+1]] == values[order[i]] {
+			j++
+		}
+		rank := float64(i+j)/2 + 1
+		for k := i; k <= j; k++ {
+			result[order[k]] = rank
+		}
+		i = j + 1
+	}
+	return result
+}
+
+func pearson(x, y []float64) float64 {
+	n := float64(len(x))
+	var meanX, meanY float64
+	for i := range x {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX /= n
+	meanY /= n
+
+	var cov, varX, varY float64
+	for i := range x {
+		dx, dy := x[i]-meanX, y[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return math.NaN()
+	}
+	return cov / math.Sqrt(varX*varY)
+}
+
+// Конфигурации, не доминируемые ни по модулю относительной ошибки, ни по
+// времени выполнения (обе величины минимизируются). Сравниваются только
+// результаты одной задачи. Совпадающие точки не доминируют друг друга и
+// попадают во фронт вместе. Порядок — как в GAResults.
+func (ar *AllResults) ParetoConfigs() []ExperimentResult {
+	front := make([]ExperimentResult, 0)
+	for i, candidate := range ar.GAResults {
+		dominated := false
+		for j, other := range ar.GAResults {
+			if i != j && other.TaskName == candidate.TaskName && dominates(other, candidate) {
+				dominated = true
+				break
+			}
+		}
+		if !dominated {
+			front = append(front, candidate)
+		}
+	}
+	return front
+}
+
+func dominates(a, b ExperimentResult) bool {
+	errA, errB := math.Abs(a.RelativeError), math.Abs(b.RelativeError)
+	if errA > errB || a.ExecutionTime > b.ExecutionTime {
+		return false
+	}
+	return errA < errB || a.ExecutionTime < b.ExecutionTime
+}
@@ -0,0 +1,100 @@
+package experiment
+
+import (
+	"math"
+	"time"
+
+	"lab1/ga"
+)
+
+// Результат базового (не генетического) оптимизатора на одной задаче.
+type BaselineResult struct {
+	TaskName      string  `json:"task_name"`
+	Method        string  `json:"method"`
+	BestValue     float64 `json:"best_value"`
+	ExecutionTime float64 `json:"execution_time_ms"`
+	AbsoluteError float64 `json:"absolute_error"`
+	RelativeError float64 `json:"relative_error"`
+	// Число вычислений приспособленности (равно числу итераций плюс оценка
+	// начальной температуры).
+	FitnessEvaluations int `json:"fitness_evaluations"`
+}
+
+const (
+	// Сколько случайных ходов используется для оценки начальной температуры.
+	annealingProbeMoves = 50
+	// Во сколько раз температура падает за весь отжиг.
+	annealingCoolingRange = 1e-3
+)
+
+// Имитация отжига на тех же хромосомах и функциях приспособленности, что
+// и у ГА задачи taskName: ход — инверсия одного случайного бита, ухудшение
+// на Δ принимается с вероятностью exp(-Δ/T). Начальная температура — средний
+// |Δ| случайного хода, охлаждение геометрическое до T0·annealingCoolingRange
+// за AnnealingIterations шагов.
+func (er *ExperimentRunner) runSimulatedAnnealing(taskName string, optimum float64) BaselineResult {
+	start := time.Now()
+
+	bits, fitness := functionBitsPerGene, er.functionFitnessFunc(er.Encoding)
+	if taskName == "array_search" {
+		bits, fitness = er.arrayBitsPerGene(), er.arrayFitnessFunc(er.Encoding)
+	}
+	rng := ga.NewRand(er.RandSource, er.runSeed("annealing/"+taskName, 0, 0))
+	evaluations := 0
+	evaluate := func(genes []byte) float64 {
+		evaluations++
+		return fitness(genes)
+	}
+
+	randomGenes := func() []byte {
+		genes := make([]byte, bits)
+		for i := range genes {
+			genes[i] = byte(rng.Intn(2))
+		}
+		return genes
+	}
+
+	temperature := 0.0
+	for i := 0; i < annealingProbeMoves; i++ {
+		genes := randomGenes()
+		before := evaluate(genes)
+		genes[rng.Intn(bits)] ^= 1
+		temperature += math.Abs(evaluate(genes) - before)
+	}
+	temperature /= annealingProbeMoves
+	if temperature == 0 {
+		temperature = 1
+	}
+
+	iterations := er.AnnealingIterations
+	cooling := math.Pow(annealingCoolingRange, 1/float64(iterations))
+
+	current := randomGenes()
+	currentValue := evaluate(current)
+	bestValue := currentValue
+	for i := 0; i < iterations; i++ {
+		bit := rng.Intn(bits)
+		current[bit] ^= 1
+		value := evaluate(current)
+
+		delta := currentValue - value
+		if delta <= 0 || rng.Float64() < math.Exp(-delta/temperature) {
+			currentValue = value
+			bestValue = math.Max(bestValue, value)
+		} else {
+			current[bit] ^= 1
+		}
+		temperature *= cooling
+	}
+
+	absoluteError := optimum - bestValue
+	return BaselineResult{
+		TaskName:           taskName,
+		Method:             "simulated_annealing",
+		BestValue:          bestValue,
+		ExecutionTime:      durationToMs(time.Since(start)),
+		AbsoluteError:      absoluteError,
+		RelativeError:      absoluteError / optimum,
+		FitnessEvaluations: evaluations,
+	}
+}
@@ -0,0 +1,26 @@
+package experiment
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSimulatedAnnealingConvergesOnFunction(t *testing.T) {
+	runner := newSmallRunner(1)
+	runner.AnnealingIterations = 5000
+	optimum := runner.optimumFor(runner.runLinearSearchFunction())
+
+	result := runner.runSimulatedAnnealing("function_optimization", optimum)
+	if math.Abs(result.RelativeError) > 1e-3 {
+		t.Errorf("относительная ошибка %.6f, ожидалось не больше 1e-3 (найдено %.6f, оптимум %.6f)",
+			result.RelativeError, result.BestValue, optimum)
+	}
+	if want := runner.AnnealingIterations + 1 + 2*annealingProbeMoves; result.FitnessEvaluations != want {
+		t.Errorf("вычислений приспособленности %d, ожидалось %d", result.FitnessEvaluations, want)
+	}
+
+	again := runner.runSimulatedAnnealing("function_optimization", optimum)
+	if again.BestValue != result.BestValue {
+		t.Errorf("повторный отжиг с тем же зерном нашёл %v вместо %v", again.BestValue, result.BestValue)
+	}
+}
@@ -0,0 +1,94 @@
+package experiment
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+
+	"lab1/ga"
+)
+
+// Пробный прогон: не больше autoTuneSample конфигураций сетки на задаче
+// array_search. Число потоков, отстающее от лучшего не более чем на
+// autoTuneTolerance, предпочитается большему: дальше отдача убывает.
+const (
+	autoTuneSample    = 16
+	autoTuneTolerance = 0.05
+)
+
+// Подбирает Parallelism по пропускной способности: прогоняет выборку
+// конфигураций сетки при 1, 2, 4, … потоках (не больше GOMAXPROCS и размера
+// выборки), выбирает наименьшее число потоков, чья пропускная способность
+// не хуже лучшей более чем на autoTuneTolerance, и записывает его в
+// Parallelism. В SerialMode подбор не выполняется и возвращается 1.
+func (er *ExperimentRunner) AutoTuneWorkers() (int, error) {
+	if er.SerialMode {
+		return 1, nil
+	}
+	if err := er.prepareArray(); err != nil {
+		return 0, err
+	}
+
+	sample := er.autoTuneConfigs()
+	if len(sample) == 0 {
+		return 0, fmt.Errorf("в сетке нет выполнимых конфигураций для подбора числа потоков")
+	}
+
+	maxWorkers := min(runtime.GOMAXPROCS(0), len(sample))
+	var candidates []int
+	for workers := 1; workers < maxWorkers; workers *= 2 {
+		candidates = append(candidates, workers)
+	}
+	candidates = append(candidates, maxWorkers)
+
+	throughputs := make([]float64, len(candidates))
+	best := 0
+	for i, workers := range candidates {
+		start := time.Now()
+		forEach(len(sample), workers, func(j int) {
+			er.runConfig("array_search", j, sample[j], 0, 0)
+		})
+		throughputs[i] = float64(len(sample)) / time.Since(start).Seconds()
+		fmt.Printf("Подбор потоков: %d — %.1f конфигураций/с\n", workers, throughputs[i])
+		if throughputs[i] > throughputs[best] {
+			best = i
+		}
+	}
+
+	chosen := candidates[best]
+	for i, workers := range candidates[:best] {
+		if throughputs[i] >= throughputs[best]*(1-autoTuneTolerance) {
+			chosen = workers
+			break
+		}
+	}
+
+	er.Parallelism = chosen
+	fmt.Printf("Выбрано потоков: %d\n", chosen)
+	return chosen, nil
+}
+
+// Равномерная выборка выполнимых конфигураций сетки; невыполнимые
+// пропускаются молча, о них предупредит основной прогон.
+func (er *ExperimentRunner) autoTuneConfigs() []ExperimentConfig {
+	var viable []ExperimentConfig
+	for _, config := range er.generateConfigs() {
+		gaConfig := er.gaConfig("array_search", config, 0)
+		if gaConfig.Validate() != nil {
+			continue
+		}
+		if !er.KeepNonViable && config.PopulationSize < ga.MinViablePopulation(gaConfig) {
+			continue
+		}
+		viable = append(viable, config)
+	}
+
+	if len(viable) <= autoTuneSample {
+		return viable
+	}
+	sample := make([]ExperimentConfig, autoTuneSample)
+	for i := range sample {
+		sample[i] = viable[i*len(viable)/autoTuneSample]
+	}
+	return sample
+}
@@ -0,0 +1,32 @@
+package experiment
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestAutoTuneWorkersSetsParallelism(t *testing.T) {
+	runner := newSmallRunner(0)
+	workers, err := runner.AutoTuneWorkers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if workers < 1 || workers > runtime.GOMAXPROCS(0) {
+		t.Fatalf("выбрано %d потоков, допустимо от 1 до %d", workers, runtime.GOMAXPROCS(0))
+	}
+	if runner.Parallelism != workers {
+		t.Fatalf("Parallelism = %d, выбрано %d", runner.Parallelism, workers)
+	}
+}
+
+func TestAutoTuneWorkersSkippedInSerialMode(t *testing.T) {
+	runner := newSmallRunner(3)
+	runner.SerialMode = true
+	workers, err := runner.AutoTuneWorkers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if workers != 1 || runner.Parallelism != 3 {
+		t.Fatalf("в SerialMode выбрано %d потоков, Parallelism = %d", workers, runner.Parallelism)
+	}
+}
@@ -0,0 +1,100 @@
+package experiment
+
+import "lab1/ga"
+
+// Фиксированная раскладка ExperimentConfig в хромосому (48 бит, внутри
+// каждого поля бит i имеет вес 1<<i, как в ga.BytesToInt):
+//
+//	биты  0–9   PopulationSize   0..1023
+//	биты 10–19  MaxGenerations   0..1023
+//	биты 20–29  CrossoverProb    round(p·1000), 0..1000
+//	биты 30–39  MutationProb     round(p·1000), 0..1000
+//	биты 40–41  CrossoverType    индекс в crossoverTypeCodes
+//	биты 42–47  ElitismCount     0..63
+//
+// Значения вне диапазона обрезаются, поэтому EncodeConfig/DecodeConfig
+// обратимы для конфигураций с вероятностями, кратными 0.001.
+const ConfigBits = 48
+
+var configFieldWidths = [...]int{10, 10, 10, 10, 2, 6}
+
+// Порядок определяет коды, поэтому новые типы добавляются только в конец.
+var crossoverTypeCodes = []string{"onepoint", "uniform", "arithmetic", "twopoint"}
+
+func isCrossoverType(name string) bool {
+	return crossoverTypeCode(name) >= 0
+}
+
+// Код типа скрещивания; -1 для неизвестного.
+func crossoverTypeCode(name string) int {
+	for i, known := range crossoverTypeCodes {
+		if known == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func EncodeConfig(config ExperimentConfig) []byte {
+	typeCode := max(crossoverTypeCode(config.CrossoverType), 0)
+
+	values := []int{
+		config.PopulationSize,
+		config.MaxGenerations,
+		probabilityCode(config.CrossoverProb),
+		probabilityCode(config.MutationProb),
+		typeCode,
+		config.ElitismCount,
+	}
+
+	genes := make([]byte, 0, ConfigBits)
+	for i, width := range configFieldWidths {
+		genes = append(genes, intToBits(values[i], width)...)
+	}
+	return genes
+}
+
+func DecodeConfig(genes []byte) ExperimentConfig {
+	if len(genes) < ConfigBits {
+		padded := make([]byte, ConfigBits)
+		copy(padded, genes)
+		genes = padded
+	}
+
+	values := make([]int, len(configFieldWidths))
+	offset := 0
+	for i, width := range configFieldWidths {
+		values[i] = ga.BytesToInt(genes[offset : offset+width])
+		offset += width
+	}
+
+	crossoverType := crossoverTypeCodes[0]
+	if values[4] < len(crossoverTypeCodes) {
+		crossoverType = crossoverTypeCodes[values[4]]
+	}
+
+	return ExperimentConfig{
+		PopulationSize: values[0],
+		MaxGenerations: values[1],
+		CrossoverProb:  float64(min(values[2], 1000)) / 1000,
+		MutationProb:   float64(min(values[3], 1000)) / 1000,
+		CrossoverType:  crossoverType,
+		ElitismCount:   values[5],
+	}
+}
+
+func probabilityCode(p float64) int {
+	code := int(p*1000 + 0.5)
+	return max(0, min(code, 1000))
+}
+
+func intToBits(value, width int) []byte {
+	value = max(0, min(value, (1<<width)-1))
+	bits := make([]byte, width)
+	for i := 0; i < width; i++ {
+		if value&(1<<i) != 0 {
+			bits[i] = 1
+		}
+	}
+	return bits
+}
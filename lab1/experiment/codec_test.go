@@ -0,0 +1,83 @@
+package experiment
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestConfigCodecRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		config := ExperimentConfig{
+			PopulationSize: rng.Intn(1024),
+			MaxGenerations: rng.Intn(1024),
+			CrossoverProb:  float64(rng.Intn(1001)) / 1000,
+			MutationProb:   float64(rng.Intn(1001)) / 1000,
+			CrossoverType:  crossoverTypeCodes[rng.Intn(len(crossoverTypeCodes))],
+			ElitismCount:   rng.Intn(64),
+		}
+		genes := EncodeConfig(config)
+		if len(genes) != ConfigBits {
+			t.Fatalf("длина хромосомы %d, ожидалось %d", len(genes), ConfigBits)
+		}
+		if got := DecodeConfig(genes); got != config {
+			t.Fatalf("DecodeConfig(EncodeConfig(%+v)) = %+v", config, got)
+		}
+	}
+}
+
+// Поля лежат на документированных позициях.
+func TestConfigCodecLayout(t *testing.T) {
+	genes := EncodeConfig(ExperimentConfig{
+		PopulationSize: 1,
+		MaxGenerations: 2,
+		CrossoverProb:  0.004,
+		MutationProb:   0.001,
+		CrossoverType:  "uniform",
+		ElitismCount:   1,
+	})
+	ones := []int{0, 11, 22, 30, 40, 42}
+	for i, gene := range genes {
+		want := byte(0)
+		for _, one := range ones {
+			if i == one {
+				want = 1
+			}
+		}
+		if gene != want {
+			t.Fatalf("бит %d = %d, ожидалось %d (хромосома %v)", i, gene, want, genes)
+		}
+	}
+}
+
+// Значения вне диапазона обрезаются, короткая хромосома дополняется нулями.
+func TestConfigCodecClamps(t *testing.T) {
+	got := DecodeConfig(EncodeConfig(ExperimentConfig{
+		PopulationSize: 5000,
+		MaxGenerations: -3,
+		CrossoverProb:  1.7,
+		MutationProb:   -0.2,
+		CrossoverType:  "unknown",
+		ElitismCount:   100,
+	}))
+	want := ExperimentConfig{
+		PopulationSize: 1023,
+		CrossoverProb:  1,
+		CrossoverType:  "onepoint",
+		ElitismCount:   63,
+	}
+	if got != want {
+		t.Fatalf("получено %+v, ожидалось %+v", got, want)
+	}
+
+	ones := make([]byte, ConfigBits)
+	for i := range ones {
+		ones[i] = 1
+	}
+	if got := DecodeConfig(ones); got.CrossoverProb != 1 || got.MutationProb != 1 || got.CrossoverType != "twopoint" {
+		t.Fatalf("из единичной хромосомы получено %+v", got)
+	}
+	if got := DecodeConfig([]byte{1}); got != (ExperimentConfig{PopulationSize: 1, CrossoverType: "onepoint"}) {
+		t.Fatalf("из короткой хромосомы получено %+v", got)
+	}
+}
@@ -0,0 +1,215 @@
+package experiment
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Описание эксперимента целиком: сетка параметров, настройки раннера
+// и пути вывода. Загружается из JSON через LoadConfig.
+type RunnerOptions struct {
+	Grid ParamGrid `json:"grid"`
+
+	ConvergencePolicy      string             `json:"convergence_policy"`
+	RandSource             string             `json:"rand_source"`
+	TwoPhase               bool               `json:"two_phase"`
+	PhaseSplit             float64            `json:"phase_split"`
+	KnownOptimum           map[string]float64 `json:"known_optimum"`
+	SerialMode             bool               `json:"serial_mode"`
+	MaxArrayElements       int                `json:"max_array_elements"`
+	BaselineAtGAResolution bool               `json:"baseline_at_ga_resolution"`
+	KeepNonViable          bool               `json:"keep_non_viable"`
+	TargetCIWidth          float64            `json:"target_ci_width"`
+	MaxRepetitions         int                `json:"max_repetitions"`
+	PlantedOptimumValue    float64            `json:"planted_optimum_value"`
+	Encoding               string             `json:"encoding"`
+	Parallelism            int                `json:"parallelism"`
+	AutoTuneWorkers        bool               `json:"auto_tune_workers"`
+	BaseSeed               int64              `json:"base_seed"`
+	AnnealingIterations    int                `json:"annealing_iterations"`
+	ArraySize              int                `json:"array_size"`
+	ArrayMean              float64            `json:"array_mean"`
+	ArrayStdDev            float64            `json:"array_stddev"`
+	Distribution           string             `json:"distribution"`
+
+	// CSV с массивом для первой задачи; пусто — сгенерированный массив
+	// (array_size, distribution и др.).
+	ArrayCSV string `json:"array_csv"`
+	// Файл результатов (по умолчанию results.json) и каталог графиков
+	// (по умолчанию текущий).
+	ResultsFile string `json:"results_file"`
+	PlotDir     string `json:"plot_dir"`
+	// Если задан, результаты дополнительно сохраняются в CSV
+	// (см. AllResults.SaveToCSV).
+	CSVFile string `json:"csv_file"`
+	// Если задан, результаты дополнительно сохраняются в базу SQLite
+	// (см. AllResults.SaveToSQLite).
+	SQLiteFile string `json:"sqlite_file"`
+	// Если задан, результаты ГА дописываются в этот файл по мере
+	// выполнения (NDJSON, см. ResultStreamer).
+	StreamFile string `json:"stream_file"`
+	// Ограничение времени каждого запуска ГА в формате time.ParseDuration
+	// ("30s", "1m30s"); пусто — без ограничения.
+	MaxRunDuration string `json:"max_run_duration"`
+	// Параметры мета-ГА (ExperimentRunner.MetaTune); не задано — значения
+	// по умолчанию.
+	MetaGA ExperimentConfig `json:"meta_ga"`
+}
+
+// Читает описание эксперимента из JSON. Неизвестные ключи и значения
+// вне допустимых диапазонов считаются ошибкой.
+func LoadConfig(path string) (RunnerOptions, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return RunnerOptions{}, err
+	}
+	defer file.Close()
+
+	options := RunnerOptions{ResultsFile: "results.json"}
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&options); err != nil {
+		return RunnerOptions{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if err := options.Validate(); err != nil {
+		return RunnerOptions{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return options, nil
+}
+
+// Проверяет сетку и настройки; LoadConfig вызывает её автоматически.
+func (o *RunnerOptions) Validate() error {
+	g := o.Grid
+	if len(g.PopulationSizes) == 0 || len(g.MaxGenerations) == 0 || len(g.CrossoverProbs) == 0 ||
+		len(g.MutationProbs) == 0 || len(g.CrossoverTypes) == 0 || len(g.ElitismCounts) == 0 {
+		return fmt.Errorf("grid: все списки параметров должны быть непустыми")
+	}
+
+	for _, v := range g.PopulationSizes {
+		if v <= 0 {
+			return fmt.Errorf("grid.population_sizes: ожидается положительное число, получено %d", v)
+		}
+	}
+	for _, v := range g.MaxGenerations {
+		if v <= 0 {
+			return fmt.Errorf("grid.max_generations: ожидается положительное число, получено %d", v)
+		}
+	}
+	for _, v := range g.CrossoverProbs {
+		if v < 0 || v > 1 {
+			return fmt.Errorf("grid.crossover_probs: ожидается значение в [0, 1], получено %v", v)
+		}
+	}
+	for _, v := range g.MutationProbs {
+		if v < 0 || v > 1 {
+			return fmt.Errorf("grid.mutation_probs: ожидается значение в [0, 1], получено %v", v)
+		}
+	}
+	for _, v := range g.CrossoverTypes {
+		if !isCrossoverType(v) {
+			return fmt.Errorf("grid.crossover_types: неизвестный тип скрещивания %q", v)
+		}
+	}
+	for _, v := range g.ElitismCounts {
+		if v < 0 {
+			return fmt.Errorf("grid.elitism_counts: ожидается неотрицательное число, получено %d", v)
+		}
+	}
+
+	switch o.ConvergencePolicy {
+	case "", "median", "first", "best":
+	default:
+		return fmt.Errorf("convergence_policy: неизвестное значение %q", o.ConvergencePolicy)
+	}
+	switch o.RandSource {
+	case "", "pcg", "classic":
+	default:
+		return fmt.Errorf("rand_source: неизвестное значение %q", o.RandSource)
+	}
+	switch o.Encoding {
+	case "", "binary", "gray":
+	default:
+		return fmt.Errorf("encoding: неизвестное значение %q", o.Encoding)
+	}
+	switch o.Distribution {
+	case "", "gaussian", "uniform", "exponential":
+	default:
+		return fmt.Errorf("distribution: неизвестное значение %q", o.Distribution)
+	}
+	if o.ArraySize < 0 || o.ArrayStdDev < 0 {
+		return fmt.Errorf("array_size, array_stddev: ожидаются неотрицательные значения")
+	}
+	if o.PhaseSplit < 0 || o.PhaseSplit >= 1 {
+		return fmt.Errorf("phase_split: ожидается значение в [0, 1), получено %v", o.PhaseSplit)
+	}
+	if o.MaxArrayElements < 0 {
+		return fmt.Errorf("max_array_elements: ожидается неотрицательное число, получено %d", o.MaxArrayElements)
+	}
+	if o.AnnealingIterations < 0 {
+		return fmt.Errorf("annealing_iterations: ожидается неотрицательное число, получено %d", o.AnnealingIterations)
+	}
+	if o.Parallelism < 0 {
+		return fmt.Errorf("parallelism: ожидается неотрицательное число, получено %d", o.Parallelism)
+	}
+	if o.TargetCIWidth < 0 || o.MaxRepetitions < 0 {
+		return fmt.Errorf("target_ci_width, max_repetitions: ожидаются неотрицательные значения")
+	}
+	if o.MaxRunDuration != "" {
+		if d, err := time.ParseDuration(o.MaxRunDuration); err != nil || d < 0 {
+			return fmt.Errorf("max_run_duration: ожидается неотрицательная длительность вида \"30s\", получено %q", o.MaxRunDuration)
+		}
+	}
+	if o.MetaGA != (ExperimentConfig{}) {
+		if err := metaGAConfig(o.MetaGA, func([]byte) float64 { return 0 }, 0).Validate(); err != nil {
+			return fmt.Errorf("meta_ga: %w", err)
+		}
+	}
+	if o.ResultsFile == "" {
+		return fmt.Errorf("results_file: пустой путь")
+	}
+	return nil
+}
+
+// Раннер с настройками из описания; массив из ArrayCSV загружается сразу.
+func (o RunnerOptions) NewRunner() (*ExperimentRunner, error) {
+	runner := NewExperimentRunner(o.Grid)
+	runner.ConvergencePolicy = o.ConvergencePolicy
+	runner.RandSource = o.RandSource
+	runner.TwoPhase = o.TwoPhase
+	runner.PhaseSplit = o.PhaseSplit
+	runner.KnownOptimum = o.KnownOptimum
+	runner.SerialMode = o.SerialMode
+	runner.MaxArrayElements = o.MaxArrayElements
+	runner.BaselineAtGAResolution = o.BaselineAtGAResolution
+	runner.KeepNonViable = o.KeepNonViable
+	runner.TargetCIWidth = o.TargetCIWidth
+	runner.MaxRepetitions = o.MaxRepetitions
+	runner.PlantedOptimumValue = o.PlantedOptimumValue
+	runner.Encoding = o.Encoding
+	runner.Parallelism = o.Parallelism
+	runner.BaseSeed = o.BaseSeed
+	runner.AnnealingIterations = o.AnnealingIterations
+	runner.StreamFile = o.StreamFile
+	runner.MetaGA = o.MetaGA
+	if o.MaxRunDuration != "" {
+		duration, err := time.ParseDuration(o.MaxRunDuration)
+		if err != nil {
+			return nil, fmt.Errorf("max_run_duration: %w", err)
+		}
+		runner.MaxRunDuration = duration
+	}
+	runner.ArraySize = o.ArraySize
+	runner.ArrayMean = o.ArrayMean
+	runner.ArrayStdDev = o.ArrayStdDev
+	runner.Distribution = o.Distribution
+
+	if o.ArrayCSV != "" {
+		if err := runner.LoadArrayFromCSV(o.ArrayCSV); err != nil {
+			return nil, err
+		}
+	}
+	return runner, nil
+}
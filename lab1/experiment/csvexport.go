@@ -0,0 +1,92 @@
+package experiment
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var gaCSVHeader = []string{
+	"task_name", "population_size", "max_generations", "crossover_prob",
+	"mutation_prob", "crossover_type", "elitism_count",
+	"best_fitness", "mean_fitness", "std_dev_fitness", "execution_time_ms",
+	"absolute_error", "relative_error", "normalized_fitness",
+	"repetitions", "fitness_evaluations", "termination_reason",
+}
+
+var linearCSVHeader = []string{
+	"task_name", "best_value", "worst_value", "execution_time_ms",
+	"fitness_evaluations", "sample_ratio",
+}
+
+// Сохраняет результаты ГА в CSV, по строке на конфигурацию; ряды по
+// поколениям (сходимость, энтропия и т.п.) не выгружаются. Базовые
+// результаты линейного поиска пишутся рядом, в файл с суффиксом _linear
+// (results.csv → results_linear.csv).
+func (ar *AllResults) SaveToCSV(filename string) error {
+	rows := make([][]string, 0, len(ar.GAResults)+1)
+	rows = append(rows, gaCSVHeader)
+	for _, r := range ar.GAResults {
+		c := r.Config
+		rows = append(rows, []string{
+			r.TaskName,
+			strconv.Itoa(c.PopulationSize),
+			strconv.Itoa(c.MaxGenerations),
+			formatCSVFloat(c.CrossoverProb),
+			formatCSVFloat(c.MutationProb),
+			c.CrossoverType,
+			strconv.Itoa(c.ElitismCount),
+			formatCSVFloat(r.BestFitness),
+			formatCSVFloat(r.MeanFitness),
+			formatCSVFloat(r.StdDevFitness),
+			formatCSVFloat(r.ExecutionTime),
+			formatCSVFloat(r.AbsoluteError),
+			formatCSVFloat(r.RelativeError),
+			formatCSVFloat(r.NormalizedFitness),
+			strconv.Itoa(r.Repetitions),
+			formatCSVFloat(r.FitnessEvaluations),
+			r.TerminationReason,
+		})
+	}
+	if err := writeCSV(filename, rows); err != nil {
+		return err
+	}
+
+	rows = [][]string{linearCSVHeader}
+	for _, r := range ar.LinearSearchResults {
+		rows = append(rows, []string{
+			r.TaskName,
+			formatCSVFloat(r.BestValue),
+			formatCSVFloat(r.WorstValue),
+			formatCSVFloat(r.ExecutionTime),
+			strconv.Itoa(r.FitnessEvaluations),
+			formatCSVFloat(r.SampleRatio),
+		})
+	}
+	return writeCSV(linearCSVPath(filename), rows)
+}
+
+func linearCSVPath(filename string) string {
+	ext := filepath.Ext(filename)
+	return strings.TrimSuffix(filename, ext) + "_linear" + ext
+}
+
+func formatCSVFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func writeCSV(filename string, rows [][]string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return file.Close()
+}
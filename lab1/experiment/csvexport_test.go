@@ -0,0 +1,88 @@
+package experiment
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func readCSV(t *testing.T, path string) [][]string {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rows
+}
+
+// CSV читается обратно: заголовок, по строке на результат и те же
+// значения полей, включая дробные числа без потери точности.
+func TestSaveToCSVRoundTrip(t *testing.T) {
+	results := &AllResults{
+		LinearSearchResults: []LinearSearchResult{
+			{TaskName: "array_search", BestValue: 412.5, WorstValue: -3, ExecutionTime: 1.25, FitnessEvaluations: 1000, SampleRatio: 1},
+		},
+		GAResults: []ExperimentResult{
+			{
+				TaskName:          "array_search",
+				Config:            ExperimentConfig{PopulationSize: 20, MaxGenerations: 10, CrossoverProb: 0.8, MutationProb: 0.05, CrossoverType: "uniform", ElitismCount: 1},
+				BestFitness:       412.5,
+				MeanFitness:       1.0 / 3,
+				Repetitions:       5,
+				TerminationReason: "max_generations",
+			},
+			{
+				TaskName:          "function_optimization",
+				Config:            ExperimentConfig{PopulationSize: 50, MaxGenerations: 30, CrossoverProb: 0.6, MutationProb: 0.1, CrossoverType: "twopoint", ElitismCount: 2},
+				BestFitness:       -1.899,
+				StdDevFitness:     1e-12,
+				Repetitions:       7,
+				TerminationReason: "stagnation",
+			},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "results.csv")
+	if err := results.SaveToCSV(path); err != nil {
+		t.Fatal(err)
+	}
+
+	rows := readCSV(t, path)
+	if !slices.Equal(rows[0], gaCSVHeader) {
+		t.Fatalf("заголовок %v, ожидался %v", rows[0], gaCSVHeader)
+	}
+	if len(rows) != len(results.GAResults)+1 {
+		t.Fatalf("%d строк, ожидалось %d", len(rows), len(results.GAResults)+1)
+	}
+	column := func(row []string, name string) string {
+		return row[slices.Index(gaCSVHeader, name)]
+	}
+	for i, r := range results.GAResults {
+		row := rows[i+1]
+		mean, err := strconv.ParseFloat(column(row, "mean_fitness"), 64)
+		if err != nil || mean != r.MeanFitness {
+			t.Fatalf("строка %d: mean_fitness %q, ожидалось %v", i+1, column(row, "mean_fitness"), r.MeanFitness)
+		}
+		if column(row, "crossover_type") != r.Config.CrossoverType ||
+			column(row, "population_size") != strconv.Itoa(r.Config.PopulationSize) ||
+			column(row, "termination_reason") != r.TerminationReason {
+			t.Fatalf("строка %d: %v", i+1, row)
+		}
+	}
+
+	linear := readCSV(t, filepath.Join(filepath.Dir(path), "results_linear.csv"))
+	if !slices.Equal(linear[0], linearCSVHeader) || len(linear) != 2 {
+		t.Fatalf("файл линейного поиска: %v", linear)
+	}
+	if linear[1][1] != "412.5" {
+		t.Fatalf("best_value %q, ожидалось 412.5", linear[1][1])
+	}
+}
@@ -0,0 +1,149 @@
+package experiment
+
+import (
+	"fmt"
+	"slices"
+
+	"lab1/ga"
+)
+
+// Параметры мета-ГА по умолчанию (см. ExperimentRunner.MetaGA).
+var defaultMetaGA = ExperimentConfig{
+	PopulationSize: 10,
+	MaxGenerations: 10,
+	CrossoverProb:  0.8,
+	MutationProb:   0.1,
+	CrossoverType:  "uniform",
+	ElitismCount:   1,
+}
+
+// Итог MetaTune: лучшая конфигурация мета-ГА рядом с оптимумом полного
+// перебора сетки по той же целевой функции.
+type MetaTuneResult struct {
+	Best            ExperimentConfig
+	BestFitness     float64
+	GridBest        ExperimentConfig
+	GridBestFitness float64
+	// Конфигурации, оценённые мета-ГА, и размер сетки.
+	Evaluated int
+	GridSize  int
+}
+
+// Конфигурация ГА, перебирающего конфигурации сетки: параметры — params
+// (нулевые — defaultMetaGA), хромосома — ConfigBits битов раскладки
+// EncodeConfig.
+func metaGAConfig(params ExperimentConfig, fitness func([]byte) float64, seed int64) ga.Config {
+	if params == (ExperimentConfig{}) {
+		params = defaultMetaGA
+	}
+	return ga.Config{
+		PopulationSize: params.PopulationSize,
+		MaxGenerations: params.MaxGenerations,
+		CrossoverProb:  params.CrossoverProb,
+		MutationProb:   params.MutationProb,
+		CrossoverType:  params.CrossoverType,
+		ElitismCount:   params.ElitismCount,
+		BitsPerGene:    ConfigBits,
+		FitnessFunc:    fitness,
+		Seed:           seed,
+	}
+}
+
+// Мета-ГА с параметрами MetaGA: конфигурация кодируется целиком
+// в раскладке EncodeConfig, особь декодируется DecodeConfig и приводится
+// к ближайшей конфигурации сетки (см. snapToGrid), приспособленность
+// конфигурации — значение objective. Затем сетка перебирается полностью
+// (уже оценённые конфигурации не пересчитываются), и найденное мета-ГА
+// сравнивается с оптимумом перебора.
+func (er *ExperimentRunner) MetaTune(objective func(ExperimentConfig) float64) (MetaTuneResult, error) {
+	grid := er.paramGrid
+	configs := er.generateConfigs()
+	if len(configs) == 0 {
+		return MetaTuneResult{}, fmt.Errorf("мета-ГА: пустая сетка параметров")
+	}
+
+	decode := func(genes []byte) ExperimentConfig {
+		return snapToGrid(grid, DecodeConfig(genes))
+	}
+
+	evaluated := make(map[ExperimentConfig]float64)
+	evaluate := func(config ExperimentConfig) float64 {
+		if value, ok := evaluated[config]; ok {
+			return value
+		}
+		value := objective(config)
+		evaluated[config] = value
+		return value
+	}
+
+	algorithm, err := ga.NewGeneticAlgorithmChecked(metaGAConfig(er.MetaGA, func(genes []byte) float64 {
+		return evaluate(decode(genes))
+	}, er.runSeed("metatune", 0, 0)))
+	if err != nil {
+		return MetaTuneResult{}, fmt.Errorf("мета-ГА: %w", err)
+	}
+	best, _ := algorithm.Run()
+	if err := algorithm.Err(); err != nil {
+		return MetaTuneResult{}, fmt.Errorf("мета-ГА: %w", err)
+	}
+
+	result := MetaTuneResult{
+		Best:      decode(best.Genes),
+		Evaluated: len(evaluated),
+		GridSize:  len(configs),
+	}
+	result.BestFitness = evaluated[result.Best]
+
+	result.GridBest = configs[0]
+	result.GridBestFitness = evaluate(configs[0])
+	for _, config := range configs[1:] {
+		if value := evaluate(config); value > result.GridBestFitness {
+			result.GridBest, result.GridBestFitness = config, value
+		}
+	}
+
+	fmt.Printf("Мета-ГА: оценено %d из %d конфигураций сетки (сэкономлено %d)\n",
+		result.Evaluated, result.GridSize, result.GridSize-result.Evaluated)
+	fmt.Printf("Мета-ГА:         %+v, приспособленность %.6f\n", result.Best, result.BestFitness)
+	fmt.Printf("Полный перебор:  %+v, приспособленность %.6f\n", result.GridBest, result.GridBestFitness)
+
+	return result, nil
+}
+
+// Приводит декодированную конфигурацию к сетке: числовые поля — к ближайшему
+// значению сетки (при равенстве расстояний — к более раннему), тип
+// скрещивания сохраняется, если он есть в сетке, иначе берётся тип сетки
+// с номером, равным коду типа по модулю их числа.
+func snapToGrid(grid ParamGrid, config ExperimentConfig) ExperimentConfig {
+	crossoverType := config.CrossoverType
+	if !slices.Contains(grid.CrossoverTypes, crossoverType) {
+		code := max(crossoverTypeCode(crossoverType), 0)
+		crossoverType = grid.CrossoverTypes[code%len(grid.CrossoverTypes)]
+	}
+
+	return ExperimentConfig{
+		PopulationSize: nearest(grid.PopulationSizes, config.PopulationSize),
+		MaxGenerations: nearest(grid.MaxGenerations, config.MaxGenerations),
+		CrossoverProb:  nearest(grid.CrossoverProbs, config.CrossoverProb),
+		MutationProb:   nearest(grid.MutationProbs, config.MutationProb),
+		CrossoverType:  crossoverType,
+		ElitismCount:   nearest(grid.ElitismCounts, config.ElitismCount),
+	}
+}
+
+func nearest[T int | float64](values []T, target T) T {
+	distance := func(v T) T {
+		if v > target {
+			return v - target
+		}
+		return target - v
+	}
+
+	best := values[0]
+	for _, v := range values[1:] {
+		if distance(v) < distance(best) {
+			best = v
+		}
+	}
+	return best
+}
@@ -0,0 +1,119 @@
+package experiment
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func inGrid(grid ParamGrid, config ExperimentConfig) bool {
+	return slices.Contains(grid.PopulationSizes, config.PopulationSize) &&
+		slices.Contains(grid.MaxGenerations, config.MaxGenerations) &&
+		slices.Contains(grid.CrossoverProbs, config.CrossoverProb) &&
+		slices.Contains(grid.MutationProbs, config.MutationProb) &&
+		slices.Contains(grid.CrossoverTypes, config.CrossoverType) &&
+		slices.Contains(grid.ElitismCounts, config.ElitismCount)
+}
+
+// Мета-ГА перебирает только конфигурации сетки и находит максимум
+// сепарабельной целевой функции.
+func TestMetaTuneFindsGridOptimum(t *testing.T) {
+	grid := smallGrid()
+	grid.PopulationSizes = []int{10, 20, 30}
+	runner := newSmallRunner(1)
+	runner.paramGrid = grid
+
+	want := ExperimentConfig{
+		PopulationSize: 30,
+		MaxGenerations: 10,
+		CrossoverProb:  0.8,
+		MutationProb:   0.1,
+		CrossoverType:  "uniform",
+		ElitismCount:   1,
+	}
+	evaluated := make(map[ExperimentConfig]bool)
+	objective := func(config ExperimentConfig) float64 {
+		if !inGrid(grid, config) {
+			t.Fatalf("оценена конфигурация вне сетки: %+v", config)
+		}
+		if evaluated[config] {
+			t.Fatalf("конфигурация оценена повторно: %+v", config)
+		}
+		evaluated[config] = true
+
+		score := float64(config.PopulationSize + config.MaxGenerations)
+		score += config.MutationProb * 100
+		if config.CrossoverType == "uniform" {
+			score += 10
+		}
+		return score
+	}
+
+	result, err := runner.MetaTune(objective)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Best != want {
+		t.Fatalf("MetaTune вернул %+v, ожидалось %+v", result.Best, want)
+	}
+	if result.GridBest != want || result.BestFitness != result.GridBestFitness {
+		t.Fatalf("оптимум перебора %+v (%v), у мета-ГА %v", result.GridBest, result.GridBestFitness, result.BestFitness)
+	}
+	if result.GridSize != 3*2*2*2 || result.Evaluated > result.GridSize {
+		t.Fatalf("оценено %d из %d", result.Evaluated, result.GridSize)
+	}
+}
+
+// Параметры мета-ГА берутся из MetaGA; некорректные — ошибка.
+func TestMetaTuneUsesRunnerParams(t *testing.T) {
+	runner := newSmallRunner(1)
+	objective := func(config ExperimentConfig) float64 { return float64(config.PopulationSize) }
+
+	runner.MetaGA = defaultMetaGA
+	runner.MetaGA.PopulationSize = 4
+	runner.MetaGA.MaxGenerations = 1
+	small, err := runner.MetaTune(objective)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if small.Evaluated > 4*2 {
+		t.Fatalf("мета-ГА с популяцией 4 на 2 поколениях оценил %d конфигураций", small.Evaluated)
+	}
+
+	runner.MetaGA.CrossoverProb = 2
+	if _, err := runner.MetaTune(objective); err == nil {
+		t.Fatal("нет ошибки при некорректных параметрах мета-ГА")
+	}
+
+	options := RunnerOptions{Grid: smallGrid(), ResultsFile: "results.json", MetaGA: runner.MetaGA}
+	if err := options.Validate(); err == nil || !strings.Contains(err.Error(), "meta_ga") {
+		t.Fatalf("Validate вернул %v, ожидалась ошибка meta_ga", err)
+	}
+}
+
+func TestSnapToGrid(t *testing.T) {
+	grid := smallGrid()
+	grid.PopulationSizes = []int{10, 20, 30}
+	tests := []struct {
+		decoded, want ExperimentConfig
+	}{
+		{
+			ExperimentConfig{PopulationSize: 0, MaxGenerations: 1023, CrossoverProb: 0, MutationProb: 0.074, CrossoverType: "uniform", ElitismCount: 63},
+			ExperimentConfig{PopulationSize: 10, MaxGenerations: 10, CrossoverProb: 0.8, MutationProb: 0.05, CrossoverType: "uniform", ElitismCount: 1},
+		},
+		{
+			// 15 и 25 равноудалены от соседей: берётся более раннее значение.
+			ExperimentConfig{PopulationSize: 25, MaxGenerations: 7, CrossoverProb: 1, MutationProb: 0.076, CrossoverType: "arithmetic", ElitismCount: 0},
+			ExperimentConfig{PopulationSize: 20, MaxGenerations: 5, CrossoverProb: 0.8, MutationProb: 0.1, CrossoverType: "onepoint", ElitismCount: 1},
+		},
+		{
+			ExperimentConfig{PopulationSize: 26, MaxGenerations: 8, CrossoverType: "twopoint"},
+			ExperimentConfig{PopulationSize: 30, MaxGenerations: 10, CrossoverProb: 0.8, MutationProb: 0.05, CrossoverType: "uniform", ElitismCount: 1},
+		},
+	}
+	for _, tt := range tests {
+		if got := snapToGrid(grid, tt.decoded); got != tt.want {
+			t.Errorf("snapToGrid(%+v) = %+v, ожидалось %+v", tt.decoded, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package experiment
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Получатель сообщений о ходе выполнения конфигураций одной задачи:
+// done из total выполнено, msg — оценка оставшегося времени. Вызовы
+// сериализованы, даже если конфигурации выполняются параллельно.
+type ProgressReporter interface {
+	Update(done, total int, msg string)
+}
+
+// Печатает прогресс в стандартный вывод; используется по умолчанию.
+type ConsoleReporter struct{}
+
+func (ConsoleReporter) Update(done, total int, msg string) {
+	fmt.Printf("Прогресс: %.1f%% (%d/%d конфигураций), %s\n",
+		float64(done)/float64(total)*100, done, total, msg)
+}
+
+// Отбрасывает сообщения о прогрессе.
+type SilentReporter struct{}
+
+func (SilentReporter) Update(done, total int, msg string) {}
+
+type progressTracker struct {
+	mu         sync.Mutex
+	total      int
+	done       int
+	interval   time.Duration
+	reporter   ProgressReporter
+	start      time.Time
+	lastReport time.Time
+}
+
+func newProgressTracker(total int, interval time.Duration, reporter ProgressReporter) *progressTracker {
+	now := time.Now()
+	return &progressTracker{
+		total:      total,
+		interval:   interval,
+		reporter:   reporter,
+		start:      now,
+		lastReport: now,
+	}
+}
+
+// Оставшееся время оценивается по среднему времени уже выполненных конфигураций.
+func (pt *progressTracker) step() {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+
+	pt.done++
+
+	now := time.Now()
+	if pt.done < pt.total && now.Sub(pt.lastReport) < pt.interval {
+		return
+	}
+	pt.lastReport = now
+
+	elapsed := now.Sub(pt.start)
+	perConfig := elapsed / time.Duration(pt.done)
+	remaining := perConfig * time.Duration(pt.total-pt.done)
+
+	pt.reporter.Update(pt.done, pt.total, fmt.Sprintf("осталось ~%v", remaining.Round(time.Second)))
+}
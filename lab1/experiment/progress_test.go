@@ -0,0 +1,60 @@
+package experiment
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+type progressUpdate struct {
+	done, total int
+}
+
+// Запоминает все сообщения о прогрессе.
+type capturingReporter struct {
+	mu      sync.Mutex
+	updates []progressUpdate
+}
+
+func (r *capturingReporter) Update(done, total int, msg string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.updates = append(r.updates, progressUpdate{done, total})
+}
+
+func TestProgressReporterUpdates(t *testing.T) {
+	configs := len(newSmallRunner(1).generateConfigs())
+	for _, parallelism := range []int{1, 4} {
+		// Без прореживания сообщение приходит после каждой конфигурации
+		// обеих задач, по порядку.
+		reporter := &capturingReporter{}
+		runner := newSmallRunner(parallelism)
+		runner.SetProgressReporter(reporter)
+		runner.SetProgressInterval(0)
+		if _, err := runner.RunAllExperiments(); err != nil {
+			t.Fatal(err)
+		}
+		if len(reporter.updates) != 2*configs {
+			t.Fatalf("потоков %d: %d сообщений, ожидалось %d", parallelism, len(reporter.updates), 2*configs)
+		}
+		for i, update := range reporter.updates {
+			if want := (progressUpdate{i%configs + 1, configs}); update != want {
+				t.Fatalf("потоков %d: сообщение %d = %+v, ожидалось %+v", parallelism, i, update, want)
+			}
+		}
+
+		// При большом интервале остаётся только итоговое сообщение каждой задачи.
+		reporter = &capturingReporter{}
+		runner = newSmallRunner(parallelism)
+		runner.SetProgressReporter(reporter)
+		runner.SetProgressInterval(time.Hour)
+		if _, err := runner.RunAllExperiments(); err != nil {
+			t.Fatal(err)
+		}
+		want := []progressUpdate{{configs, configs}, {configs, configs}}
+		if !slices.Equal(reporter.updates, want) {
+			t.Fatalf("потоков %d: сообщения %+v, ожидалось %+v", parallelism, reporter.updates, want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package experiment
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Повтор на свежем, так же настроенном раннере воспроизводит каждый
+// сохранённый запуск, в том числе на прореженном и загруженном из CSV
+// массиве.
+func TestReplayReproducesRuns(t *testing.T) {
+	values := make([]string, 3000)
+	for i := range values {
+		values[i] = fmt.Sprint((i * 7919) % 3001)
+	}
+	csvPath := filepath.Join(t.TempDir(), "array.csv")
+	if err := os.WriteFile(csvPath, []byte(strings.Join(values, "\n")), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		configure func(*ExperimentRunner) error
+	}{
+		{"generated", func(*ExperimentRunner) error { return nil }},
+		{"downsampled", func(r *ExperimentRunner) error {
+			r.MaxArrayElements = 500
+			return nil
+		}},
+		{"csv downsampled", func(r *ExperimentRunner) error {
+			r.MaxArrayElements = 700
+			return r.LoadArrayFromCSV(csvPath)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newRunner := func() *ExperimentRunner {
+				runner := newSmallRunner(1)
+				if err := tt.configure(runner); err != nil {
+					t.Fatal(err)
+				}
+				return runner
+			}
+
+			results, err := newRunner().RunAllExperiments()
+			if err != nil {
+				t.Fatal(err)
+			}
+			replayer := newRunner()
+			for _, result := range results.GAResults {
+				for run := range result.Seeds {
+					best, _, err := replayer.Replay(result, run)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if best.Fitness != result.RunFitnessValues[run] {
+						t.Fatalf("%s %+v, повтор %d: %v, сохранено %v",
+							result.TaskName, result.Config, run, best.Fitness, result.RunFitnessValues[run])
+					}
+				}
+			}
+		})
+	}
+}
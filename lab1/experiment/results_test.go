@@ -0,0 +1,75 @@
+package experiment
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"lab1/utils"
+)
+
+func saveJSON(t *testing.T, results *AllResults) []byte {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "results.json")
+	if err := results.SaveToJSON(path); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// Два запуска с одним BaseSeed дают один и тот же JSON (кроме времени).
+func TestFixedBaseSeedIsReproducible(t *testing.T) {
+	first, err := newSmallRunner(1).RunAllExperiments()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := newSmallRunner(1).RunAllExperiments()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(saveJSON(t, withoutTimings(first)), saveJSON(t, withoutTimings(second))) {
+		t.Fatal("JSON двух запусков с одинаковым BaseSeed различается")
+	}
+}
+
+// Сохранение, загрузка через utils.LoadResults и повторное сохранение
+// тем же кодировщиком дают тот же файл байт в байт: типы utils не теряют
+// и не переупорядочивают поля.
+func TestResultsJSONRoundTrip(t *testing.T) {
+	runner := newSmallRunner(1)
+	runner.AnnealingIterations = 200
+	results, err := runner.RunAllExperiments()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "results.json")
+	if err := results.SaveToJSON(path); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := utils.LoadResults(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var resaved bytes.Buffer
+	encoder := json.NewEncoder(&resaved)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(loaded); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(saved, resaved.Bytes()) {
+		t.Fatalf("файл после загрузки и повторного сохранения отличается:\n%s\n---\n%s", saved, resaved.Bytes())
+	}
+}
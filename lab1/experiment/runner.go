@@ -1,23 +1,78 @@
 package experiment
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"io"
 	"math"
-	"math/rand"
 	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"golang.org/x/sync/errgroup"
+
 	"lab1/ga"
 )
 
 type ParamGrid struct {
-	PopulationSizes []int
-	MaxGenerations  []int
-	CrossoverProbs  []float64
-	MutationProbs   []float64
-	CrossoverTypes  []string
-	ElitismCounts   []int
+	PopulationSizes []int     `json:"population_sizes"`
+	MaxGenerations  []int     `json:"max_generations"`
+	CrossoverProbs  []float64 `json:"crossover_probs"`
+	MutationProbs   []float64 `json:"mutation_probs"`
+	CrossoverTypes  []string  `json:"crossover_types"`
+	ElitismCounts   []int     `json:"elitism_counts"`
+}
+
+// Fix закрепляет параметр одним значением, остальные продолжают перебираться.
+// field — JSON-ключ ExperimentConfig (например "population_size")
+// или имя соответствующего поля Go.
+func (pg *ParamGrid) Fix(field string, value interface{}) error {
+	switch field {
+	case "population_size", "PopulationSize":
+		v, ok := value.(int)
+		if !ok || v <= 0 {
+			return fmt.Errorf("%s: ожидается положительное int, получено %v", field, value)
+		}
+		pg.PopulationSizes = []int{v}
+	case "max_generations", "MaxGenerations":
+		v, ok := value.(int)
+		if !ok || v <= 0 {
+			return fmt.Errorf("%s: ожидается положительное int, получено %v", field, value)
+		}
+		pg.MaxGenerations = []int{v}
+	case "crossover_prob", "CrossoverProb":
+		v, ok := value.(float64)
+		if !ok || v < 0 || v > 1 {
+			return fmt.Errorf("%s: ожидается float64 в [0, 1], получено %v", field, value)
+		}
+		pg.CrossoverProbs = []float64{v}
+	case "mutation_prob", "MutationProb":
+		v, ok := value.(float64)
+		if !ok || v < 0 || v > 1 {
+			return fmt.Errorf("%s: ожидается float64 в [0, 1], получено %v", field, value)
+		}
+		pg.MutationProbs = []float64{v}
+	case "crossover_type", "CrossoverType":
+		v, ok := value.(string)
+		if !ok || !isCrossoverType(v) {
+			return fmt.Errorf("%s: неизвестный тип скрещивания %v", field, value)
+		}
+		pg.CrossoverTypes = []string{v}
+	case "elitism_count", "ElitismCount":
+		v, ok := value.(int)
+		if !ok || v < 0 {
+			return fmt.Errorf("%s: ожидается неотрицательное int, получено %v", field, value)
+		}
+		pg.ElitismCounts = []int{v}
+	default:
+		return fmt.Errorf("неизвестный параметр сетки %q", field)
+	}
+	return nil
 }
 
 type ExperimentConfig struct {
@@ -30,26 +85,55 @@ type ExperimentConfig struct {
 }
 
 type ExperimentResult struct {
-	TaskName      string           `json:"task_name"`
-	Config        ExperimentConfig `json:"config"`
-	BestFitness   float64          `json:"best_fitness"`
-	MeanFitness   float64          `json:"mean_fitness"`
-	StdDevFitness float64          `json:"std_dev_fitness"`
-	ExecutionTime float64          `json:"execution_time_ms"`
-	AbsoluteError float64          `json:"absolute_error"`
-	RelativeError float64          `json:"relative_error"`
-	Convergence   []float64        `json:"convergence"`
+	TaskName          string           `json:"task_name"`
+	Config            ExperimentConfig `json:"config"`
+	BestFitness       float64          `json:"best_fitness"`
+	MeanFitness       float64          `json:"mean_fitness"`
+	StdDevFitness     float64          `json:"std_dev_fitness"`
+	ExecutionTime     float64          `json:"execution_time_ms"`
+	AbsoluteError     float64          `json:"absolute_error"`
+	RelativeError     float64          `json:"relative_error"`
+	Convergence       []float64        `json:"convergence"`
+	MeanConvergence   []float64        `json:"mean_convergence"`
+	StdDevConvergence []float64        `json:"stddev_convergence"`
+	NormalizedFitness float64          `json:"normalized_fitness"`
+	Seeds             []int64          `json:"seeds"`
+	Repetitions       int              `json:"repetitions"`
+	// Повторы, завершившиеся ошибкой; в статистику и Seeds не входят.
+	FailedRuns int `json:"failed_runs"`
+	// Среднее число вычислений приспособленности на повтор.
+	FitnessEvaluations float64 `json:"fitness_evaluations"`
+	// Итоговая приспособленность каждого повтора, в порядке Seeds.
+	RunFitnessValues []float64 `json:"run_fitness_values"`
+
+	SelectionDifferential []float64 `json:"selection_differential"`
+	SelectionResponse     []float64 `json:"selection_response"`
+	DistinctIndividuals   int       `json:"distinct_individuals"`
+	DistinctPerGeneration []int     `json:"distinct_per_generation"`
+	Entropy               []float64 `json:"entropy"`
+	ConvergenceRate       float64   `json:"convergence_rate"`
+	ConvergenceRateR2     float64   `json:"convergence_rate_r2"`
+	// Самая частая причина остановки среди повторов и разбивка по причинам.
+	TerminationReason string         `json:"termination_reason"`
+	TerminationCounts map[string]int `json:"termination_counts"`
 }
 
 type LinearSearchResult struct {
 	TaskName      string  `json:"task_name"`
 	BestValue     float64 `json:"best_value"`
+	WorstValue    float64 `json:"worst_value"`
 	ExecutionTime float64 `json:"execution_time_ms"`
+	// Число вычисленных точек (элементов массива или значений функции).
+	FitnessEvaluations int `json:"fitness_evaluations"`
+	// Доля исходного массива в выборке; 0 — массив не прореживался.
+	SampleRatio float64 `json:"sample_ratio,omitempty"`
 }
 
 type AllResults struct {
 	LinearSearchResults []LinearSearchResult `json:"linear_search_results"`
 	GAResults           []ExperimentResult   `json:"ga_results"`
+	// Пусто, если отжиг не запускался (AnnealingIterations == 0).
+	SimulatedAnnealingResults []BaselineResult `json:"simulated_annealing_results,omitempty"`
 }
 
 func (ar *AllResults) SaveToJSON(filename string) error {
@@ -65,35 +149,178 @@ func (ar *AllResults) SaveToJSON(filename string) error {
 }
 
 type ExperimentRunner struct {
-	paramGrid ParamGrid
-	arrayData []float64
+	paramGrid        ParamGrid
+	arrayData        []float64
+	computeDuration  time.Duration
+	progressInterval time.Duration
+	progressReporter ProgressReporter
+	// Какой повтор сохраняется в Convergence: "median" (по умолчанию) —
+	// повтор с медианной итоговой приспособленностью, "first" — первый,
+	// "best" — лучший.
+	ConvergencePolicy string
+	// Источник случайных чисел для массива и ГА: "pcg" (по умолчанию)
+	// или "classic" для воспроизведения результатов прежних версий.
+	RandSource string
+	// Двухфазный ГА для задачи оптимизации функции (см. ga.Config.TwoPhase).
+	TwoPhase   bool
+	PhaseSplit float64
+	// Известный точный оптимум по имени задачи; если задан, ошибки
+	// считаются относительно него, а не результата линейного поиска.
+	KnownOptimum map[string]float64
+	// Строго последовательный режим: раннер не запускает ни одной горутины,
+	// поэтому при фиксированных зёрнах результаты детерминированы всегда,
+	// даже если пользовательская функция приспособленности не потокобезопасна.
+	// Любые параллельные режимы раннера обязаны учитывать этот флаг.
+	SerialMode bool
+	// Если массив длиннее, он детерминированно прореживается с постоянным
+	// шагом до MaxArrayElements элементов; оптимум тогда ищется по выборке.
+	MaxArrayElements int
+	sampleRatio      float64
+	// Базовый линейный поиск для функции перебирает ровно 2^BitsPerGene
+	// точек, достижимых декодированием ГА, вместо равномерной сетки из
+	// миллиона шагов: ошибка ГА тогда не включает погрешность кодирования.
+	BaselineAtGAResolution bool
+	// Конфигурации с PopulationSize меньше ga.MinViablePopulation по
+	// умолчанию пропускаются; с этим флагом они выполняются с предупреждением.
+	KeepNonViable bool
+	// Адаптивное число повторов: если TargetCIWidth > 0, повторы
+	// добавляются по одному, пока ширина 95%-го доверительного интервала
+	// средней приспособленности больше TargetCIWidth, но не более
+	// MaxRepetitions (по умолчанию defaultMaxRepetitions).
+	TargetCIWidth  float64
+	MaxRepetitions int
+	// Если не 0, в сгенерированный массив в случайную позицию помещается
+	// это значение («иголка в стоге сена»). Позиция сохраняется, и для
+	// задачи поиска в массиве оптимум известен точно.
+	PlantedOptimumValue float64
+	plantedIndex        int
+	// Кодировка хромосом обеих задач: "binary" (по умолчанию) или "gray".
+	Encoding string
+	// Функция второй задачи и её область определения; по умолчанию
+	// sin(x)+sin(10/3·x) на [2.7, 7.5]. Используются и линейным поиском,
+	// и ГА.
+	TargetFunc func(float64) float64
+	Domain     [2]float64
+	// Зерно всего эксперимента: зерно каждого запуска ГА выводится из
+	// BaseSeed, задачи, номера конфигурации и номера повтора, поэтому
+	// RunAllExperiments воспроизводим целиком. 0 — зёрна от текущего времени.
+	BaseSeed int64
+	// Если больше 0, для каждой задачи дополнительно выполняется имитация
+	// отжига с этим числом итераций (см. runSimulatedAnnealing).
+	AnnealingIterations int
+	// Сгенерированный массив первой задачи: ArraySize элементов (по умолчанию
+	// defaultArraySize) с распределением Distribution — "gaussian" (по
+	// умолчанию), "uniform" или "exponential" — со средним ArrayMean и
+	// стандартным отклонением ArrayStdDev (по умолчанию defaultArrayStdDev).
+	// Не используются, если массив загружен из CSV.
+	ArraySize    int
+	ArrayMean    float64
+	ArrayStdDev  float64
+	Distribution string
+	// Если задан, результат каждой конфигурации ГА дописывается в этот файл
+	// сразу по завершении (см. ResultStreamer) и в памяти не накапливается:
+	// GAResults, возвращённые RunAllExperiments, пусты, а при аварийном
+	// завершении готовые результаты не теряются. При Parallelism > 1 порядок
+	// строк — порядок завершения конфигураций.
+	StreamFile string
+	streamer   *ResultStreamer
+	// Ограничение времени каждого запуска ГА (ga.Config.MaxDuration);
+	// 0 — без ограничения.
+	MaxRunDuration time.Duration
+	// Число конфигураций, выполняемых одновременно; 0 и 1 — по одной.
+	// В SerialMode не учитывается. Порядок результатов от него не зависит,
+	// но ExecutionTime завышается конкуренцией за процессор.
+	Parallelism int
+	// Параметры мета-ГА в MetaTune; нулевое значение — defaultMetaGA.
+	MetaGA ExperimentConfig
 }
 
+const (
+	functionBitsPerGene = 16
+	defaultArraySize    = 1000000
+	defaultArrayStdDev  = 100.0
+	// Больше точек перебирать полным перебором уже нецелесообразно.
+	maxExhaustiveBits = 24
+	// Начальное число повторов каждой конфигурации.
+	baseRepetitions       = 5
+	defaultMaxRepetitions = 50
+)
+
 func NewExperimentRunner(paramGrid ParamGrid) *ExperimentRunner {
 	return &ExperimentRunner{
-		paramGrid: paramGrid,
+		paramGrid:        paramGrid,
+		progressInterval: 5 * time.Second,
+		progressReporter: ConsoleReporter{},
+		plantedIndex:     -1,
+	}
+}
+
+// Заменяет оптимизируемую функцию второй задачи и её область определения.
+func (er *ExperimentRunner) SetTargetFunction(fn func(float64) float64, domain [2]float64) {
+	er.TargetFunc = fn
+	er.Domain = domain
+}
+
+// Минимальный интервал между строками прогресса; 0 — после каждой конфигурации.
+func (er *ExperimentRunner) SetProgressInterval(interval time.Duration) {
+	er.progressInterval = interval
+}
+
+// Получатель сообщений о прогрессе; nil отключает их. Сообщения
+// прореживаются по SetProgressInterval, последнее приходит всегда.
+func (er *ExperimentRunner) SetProgressReporter(reporter ProgressReporter) {
+	if reporter == nil {
+		reporter = SilentReporter{}
 	}
+	er.progressReporter = reporter
 }
 
-func (er *ExperimentRunner) RunAllExperiments() (*AllResults, error) {
-	results := &AllResults{
+func (er *ExperimentRunner) RunAllExperiments() (results *AllResults, err error) {
+	start := time.Now()
+	defer func() {
+		er.computeDuration = time.Since(start)
+	}()
+
+	if er.StreamFile != "" {
+		streamer, err := NewResultStreamer(er.StreamFile)
+		if err != nil {
+			return nil, err
+		}
+		er.streamer = streamer
+		defer func() {
+			if closeErr := streamer.Close(); closeErr != nil && err == nil {
+				results, err = nil, closeErr
+			}
+			er.streamer = nil
+		}()
+	}
+
+	results = &AllResults{
 		LinearSearchResults: make([]LinearSearchResult, 0),
 		GAResults:           make([]ExperimentResult, 0),
 	}
 
-	fmt.Println("Генерация массива с гауссовским распределением (1,000,000 элементов)...")
-	er.arrayData = er.generateGaussianArray(1000000, 0.0, 100.0)
+	if err := er.prepareArray(); err != nil {
+		return nil, err
+	}
 
 	fmt.Println("\n--- Задача 1: Поиск максимума в массиве ---")
 	linearResult1 := er.runLinearSearchArray()
 	results.LinearSearchResults = append(results.LinearSearchResults, linearResult1)
-	fmt.Printf("Линейный поиск: значение=%.6f, время=%.2f мс\n",
-		linearResult1.BestValue, linearResult1.ExecutionTime)
+	if linearResult1.SampleRatio > 0 {
+		fmt.Printf("Линейный поиск (оптимум по выборке %.4f%% массива): значение=%.6f, время=%.2f мс\n",
+			linearResult1.SampleRatio*100, linearResult1.BestValue, linearResult1.ExecutionTime)
+	} else {
+		fmt.Printf("Линейный поиск: значение=%.6f, время=%.2f мс\n",
+			linearResult1.BestValue, linearResult1.ExecutionTime)
+	}
+
+	er.runAnnealingForTask(results, "array_search", er.optimumFor(linearResult1))
 
 	fmt.Println("Запуск генетического алгоритма с различными конфигурациями...")
-	gaResults1 := er.runGAForArray(linearResult1.BestValue)
+	gaResults1, completed1 := er.runGAForTask("array_search", er.optimumFor(linearResult1), linearResult1.WorstValue)
 	results.GAResults = append(results.GAResults, gaResults1...)
-	fmt.Printf("Выполнено %d конфигураций для задачи 1\n", len(gaResults1))
+	fmt.Printf("Выполнено %d конфигураций для задачи 1\n", completed1)
 
 	fmt.Println("\n--- Задача 2: Оптимизация математической функции ---")
 	linearResult2 := er.runLinearSearchFunction()
@@ -101,31 +328,197 @@ func (er *ExperimentRunner) RunAllExperiments() (*AllResults, error) {
 	fmt.Printf("Линейный поиск: значение=%.6f, время=%.2f мс\n",
 		linearResult2.BestValue, linearResult2.ExecutionTime)
 
+	er.runAnnealingForTask(results, "function_optimization", er.optimumFor(linearResult2))
+
 	fmt.Println("Запуск генетического алгоритма с различными конфигурациями...")
-	gaResults2 := er.runGAForFunction(linearResult2.BestValue)
+	gaResults2, completed2 := er.runGAForTask("function_optimization", er.optimumFor(linearResult2), linearResult2.WorstValue)
 	results.GAResults = append(results.GAResults, gaResults2...)
-	fmt.Printf("Выполнено %d конфигураций для задачи 2\n", len(gaResults2))
+	fmt.Printf("Выполнено %d конфигураций для задачи 2\n", completed2)
 
 	return results, nil
 }
 
-func (er *ExperimentRunner) generateGaussianArray(size int, mean, stddev float64) []float64 {
-	rng := rand.New(rand.NewSource(42))
+// Генерирует массив первой задачи, если он не загружен из CSV, и
+// прореживает его по MaxArrayElements.
+func (er *ExperimentRunner) prepareArray() error {
+	if len(er.arrayData) == 0 {
+		data, err := er.generateArray()
+		if err != nil {
+			return err
+		}
+		er.arrayData = data
+	}
+	er.downsampleArray()
+	return nil
+}
+
+func (er *ExperimentRunner) runAnnealingForTask(results *AllResults, taskName string, optimum float64) {
+	if er.AnnealingIterations <= 0 {
+		return
+	}
+	result := er.runSimulatedAnnealing(taskName, optimum)
+	results.SimulatedAnnealingResults = append(results.SimulatedAnnealingResults, result)
+	fmt.Printf("Имитация отжига: значение=%.6f, ошибка=%.4f%%, время=%.2f мс\n",
+		result.BestValue, result.RelativeError*100, result.ExecutionTime)
+}
+
+// Время последнего RunAllExperiments без сохранения результатов и графиков.
+func (er *ExperimentRunner) ComputeDuration() time.Duration {
+	return er.computeDuration
+}
+
+func (er *ExperimentRunner) LoadArrayFromCSV(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	data := make([]float64, 0)
+	line := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		line++
+
+		for _, field := range record {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			val, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return fmt.Errorf("строка %d: некорректное число %q", line, field)
+			}
+			if math.IsNaN(val) || math.IsInf(val, 0) {
+				return fmt.Errorf("строка %d: недопустимое значение %q", line, field)
+			}
+			data = append(data, val)
+		}
+	}
+
+	if len(data) == 0 {
+		return fmt.Errorf("файл %s не содержит данных", path)
+	}
+
+	minVal, maxVal := data[0], data[0]
+	for _, val := range data {
+		if val < minVal {
+			minVal = val
+		}
+		if val > maxVal {
+			maxVal = val
+		}
+	}
+
+	er.arrayData = data
+	fmt.Printf("Загружен массив из %s: %d элементов, мин=%.6f, макс=%.6f\n",
+		path, len(data), minVal, maxVal)
+
+	return nil
+}
+
+func (er *ExperimentRunner) downsampleArray() {
+	n := len(er.arrayData)
+	if er.MaxArrayElements <= 0 || n <= er.MaxArrayElements {
+		return
+	}
+
+	sample := make([]float64, er.MaxArrayElements)
+	for i := range sample {
+		sample[i] = er.arrayData[i*n/er.MaxArrayElements]
+	}
+
+	// Внедрённый оптимум не должен потеряться при прореживании.
+	if er.plantedIndex >= 0 {
+		er.plantedIndex = er.plantedIndex * er.MaxArrayElements / n
+		sample[er.plantedIndex] = er.PlantedOptimumValue
+	}
+
+	er.arrayData = sample
+	er.sampleRatio = float64(len(sample)) / float64(n)
+	fmt.Printf("Массив прорежен: %d из %d элементов (доля %.4f)\n", len(sample), n, er.sampleRatio)
+}
+
+// Все распределения сдвинуты и масштабированы так, чтобы среднее было
+// ArrayMean, а стандартное отклонение — ArrayStdDev.
+func (er *ExperimentRunner) generateArray() ([]float64, error) {
+	size := er.ArraySize
+	if size <= 0 {
+		size = defaultArraySize
+	}
+	mean, stddev := er.ArrayMean, er.ArrayStdDev
+	if stddev == 0 {
+		stddev = defaultArrayStdDev
+	}
+
+	rng := ga.NewRand(er.RandSource, 42)
+	var sample func() float64
+	switch er.Distribution {
+	case "", "gaussian":
+		fmt.Printf("Генерация массива с гауссовским распределением (%d элементов)...\n", size)
+		sample = func() float64 { return rng.NormFloat64()*stddev + mean }
+	case "uniform":
+		// Равномерное на [mean-√3σ, mean+√3σ].
+		fmt.Printf("Генерация массива с равномерным распределением (%d элементов)...\n", size)
+		halfWidth := math.Sqrt(3) * stddev
+		sample = func() float64 { return mean + (2*rng.Float64()-1)*halfWidth }
+	case "exponential":
+		fmt.Printf("Генерация массива с экспоненциальным распределением (%d элементов)...\n", size)
+		sample = func() float64 { return mean + (-math.Log(1-rng.Float64())-1)*stddev }
+	default:
+		return nil, fmt.Errorf("неизвестное распределение массива %q", er.Distribution)
+	}
+
 	arr := make([]float64, size)
-	for i := 0; i < size; i++ {
-		arr[i] = rng.NormFloat64()*stddev + mean
+	for i := range arr {
+		arr[i] = sample()
 	}
-	return arr
+
+	if er.PlantedOptimumValue != 0 && size > 0 {
+		er.plantedIndex = rng.Intn(size)
+		arr[er.plantedIndex] = er.PlantedOptimumValue
+		fmt.Printf("Внедрён оптимум %.6f в позицию %d\n", er.PlantedOptimumValue, er.plantedIndex)
+	}
+	return arr, nil
+}
+
+// Число бит хромосомы задачи поиска в массиве: наименьшее, при котором
+// декодированный индекс покрывает все элементы массива.
+func (er *ExperimentRunner) arrayBitsPerGene() int {
+	bits := 1
+	for bits < ga.MaxDecodeBits && 1<<bits < len(er.arrayData) {
+		bits++
+	}
+	return bits
+}
+
+// Позиция внедрённого оптимума в массиве задачи 1 (после прореживания)
+// или -1, если оптимум не внедрялся.
+func (er *ExperimentRunner) PlantedOptimumIndex() int {
+	return er.plantedIndex
 }
 
 func (er *ExperimentRunner) runLinearSearchArray() LinearSearchResult {
 	start := time.Now()
 
-	maxVal := er.arrayData[0]
+	maxVal, minVal := er.arrayData[0], er.arrayData[0]
 	for _, val := range er.arrayData {
 		if val > maxVal {
 			maxVal = val
 		}
+		if val < minVal {
+			minVal = val
+		}
 	}
 
 	elapsed := time.Since(start)
@@ -133,24 +526,39 @@ func (er *ExperimentRunner) runLinearSearchArray() LinearSearchResult {
 	return LinearSearchResult{
 		TaskName:      "array_search",
 		BestValue:     maxVal,
-		ExecutionTime: float64(elapsed.Milliseconds()),
+		WorstValue:    minVal,
+		ExecutionTime: durationToMs(elapsed),
+		SampleRatio:   er.sampleRatio,
+
+		FitnessEvaluations: len(er.arrayData),
 	}
 }
 
 func (er *ExperimentRunner) runLinearSearchFunction() LinearSearchResult {
 	start := time.Now()
 
-	min, max := 2.7, 7.5
+	min, max := er.domain()
 	steps := 1000000
+	gaGrid := er.BaselineAtGAResolution && functionBitsPerGene <= maxExhaustiveBits
+	if gaGrid {
+		steps = (1 << functionBitsPerGene) - 1
+	}
 	stepSize := (max - min) / float64(steps)
 
-	maxVal := -math.MaxFloat64
+	maxVal, minVal := -math.MaxFloat64, math.MaxFloat64
 	for i := 0; i <= steps; i++ {
 		x := min + float64(i)*stepSize
+		if gaGrid {
+			// Та же формула, что в ga.BytesToFloat, чтобы точки совпадали бит в бит.
+			x = min + float64(i)/float64(steps)*(max-min)
+		}
 		val := er.targetFunction(x)
 		if val > maxVal {
 			maxVal = val
 		}
+		if val < minVal {
+			minVal = val
+		}
 	}
 
 	elapsed := time.Since(start)
@@ -158,185 +566,354 @@ func (er *ExperimentRunner) runLinearSearchFunction() LinearSearchResult {
 	return LinearSearchResult{
 		TaskName:      "function_optimization",
 		BestValue:     maxVal,
-		ExecutionTime: float64(elapsed.Milliseconds()),
+		WorstValue:    minVal,
+		ExecutionTime: durationToMs(elapsed),
+
+		FitnessEvaluations: steps + 1,
 	}
 }
 
 func (er *ExperimentRunner) targetFunction(x float64) float64 {
+	if er.TargetFunc != nil {
+		return er.TargetFunc(x)
+	}
 	return math.Sin(x) + math.Sin(10.0/3.0*x)
 }
 
-func (er *ExperimentRunner) runGAForArray(linearBest float64) []ExperimentResult {
-	results := make([]ExperimentResult, 0)
+// Область определения функции; пустая или вырожденная Domain заменяется
+// стандартной [2.7, 7.5].
+func (er *ExperimentRunner) domain() (float64, float64) {
+	if er.Domain[1] > er.Domain[0] {
+		return er.Domain[0], er.Domain[1]
+	}
+	return 2.7, 7.5
+}
+
+// Возвращает результаты выполненных конфигураций и их число. При потоковой
+// записи результаты только пишутся в StreamFile, и срез пуст.
+func (er *ExperimentRunner) runGAForTask(taskName string, optimum, worst float64) ([]ExperimentResult, int) {
 	configs := er.generateConfigs()
+	progress := newProgressTracker(len(configs), er.progressInterval, er.progressReporter)
+
+	// Без потоковой записи результат каждой конфигурации пишется в свою
+	// ячейку, поэтому порядок совпадает с generateConfigs при любом
+	// планировании.
+	var slots []ExperimentResult
+	if er.streamer == nil {
+		slots = make([]ExperimentResult, len(configs))
+	}
+	done := make([]bool, len(configs))
+	run := func(i int) {
+		result, ok := er.runConfig(taskName, i, configs[i], optimum, worst)
+		done[i] = ok
+		switch {
+		case !ok:
+		case er.streamer != nil:
+			// Ошибка запоминается в streamer и возвращается RunAllExperiments.
+			er.streamer.Write(result)
+		default:
+			slots[i] = result
+		}
+		progress.step()
+	}
 
-	configNum := 0
-	totalConfigs := len(configs)
+	forEach(len(configs), er.workers(), run)
 
-	for _, config := range configs {
-		configNum++
-		if configNum%10 == 0 {
-			fmt.Printf("Прогресс: %d/%d конфигураций\n", configNum, totalConfigs)
+	completed := 0
+	var results []ExperimentResult
+	for i := range configs {
+		if !done[i] {
+			continue
+		}
+		completed++
+		if slots != nil {
+			results = append(results, slots[i])
 		}
+	}
+	return results, completed
+}
 
-		runs := 5
-		fitnessValues := make([]float64, runs)
-		var totalTime time.Duration
-		var convergence []float64
-
-		for run := 0; run < runs; run++ {
-			gaConfig := ga.Config{
-				PopulationSize: config.PopulationSize,
-				MaxGenerations: config.MaxGenerations,
-				CrossoverProb:  config.CrossoverProb,
-				MutationProb:   config.MutationProb,
-				CrossoverType:  config.CrossoverType,
-				ElitismCount:   config.ElitismCount,
-				BitsPerGene:    20,
-				FitnessFunc:    er.arrayFitnessFunc(),
-				Seed:           int64(time.Now().UnixNano() + int64(run)),
-			}
+// Вызывает fn для 0..n-1 не более чем в workers горутинах; при workers <= 1
+// по порядку в текущей.
+func forEach(n, workers int, fn func(i int)) {
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
 
-			algorithm := ga.NewGeneticAlgorithm(gaConfig)
+	var g errgroup.Group
+	g.SetLimit(workers)
+	for i := 0; i < n; i++ {
+		g.Go(func() error {
+			fn(i)
+			return nil
+		})
+	}
+	g.Wait()
+}
 
-			start := time.Now()
-			best, conv := algorithm.Run()
-			elapsed := time.Since(start)
+func (er *ExperimentRunner) workers() int {
+	if er.SerialMode || er.Parallelism < 1 {
+		return 1
+	}
+	return er.Parallelism
+}
 
-			fitnessValues[run] = best.Fitness
-			totalTime += elapsed
-			if run == 0 {
-				convergence = conv
-			}
+// Выполняет повторы одной конфигурации; false — конфигурация пропущена.
+func (er *ExperimentRunner) runConfig(taskName string, configIndex int, config ExperimentConfig, optimum, worst float64) (ExperimentResult, bool) {
+	gaConfig := er.gaConfig(taskName, config, 0)
+	if err := gaConfig.Validate(); err != nil {
+		fmt.Printf("Предупреждение: конфигурация пропущена: %v\n", err)
+		return ExperimentResult{}, false
+	}
+	if minSize := ga.MinViablePopulation(gaConfig); config.PopulationSize < minSize {
+		if !er.KeepNonViable {
+			fmt.Printf("Предупреждение: конфигурация пропущена: популяция %d меньше минимальной %d (элита %d)\n",
+				config.PopulationSize, minSize, config.ElitismCount)
+			return ExperimentResult{}, false
 		}
+		fmt.Printf("Предупреждение: популяция %d меньше минимальной %d (элита %d), сходимость может быть вырожденной\n",
+			config.PopulationSize, minSize, config.ElitismCount)
+	}
 
-		meanFitness := 0.0
-		for _, f := range fitnessValues {
-			meanFitness += f
+	seeds := make([]int64, baseRepetitions)
+	for run := range seeds {
+		seeds[run] = er.runSeed(taskName, configIndex, run)
+	}
+
+	multi := ga.RunMany(gaConfig, len(seeds), seeds)
+	er.extendRepetitions(gaConfig, &multi, func(run int) int64 {
+		return er.runSeed(taskName, configIndex, run)
+	})
+	runs := len(multi.Fitnesses)
+	if failed := len(multi.Failures); failed > 0 {
+		if runs == 0 {
+			fmt.Printf("Предупреждение: конфигурация пропущена: все %d повторов завершились ошибкой: %v\n",
+				failed, multi.Failures[0].Err)
+			return ExperimentResult{}, false
 		}
-		meanFitness /= float64(runs)
+		fmt.Printf("Предупреждение: %d из %d повторов завершились ошибкой и исключены из статистики: %v\n",
+			failed, multi.Runs(), multi.Failures[0].Err)
+	}
 
-		stdDev := ga.StdDev(fitnessValues, meanFitness)
+	representative := er.representativeRun(multi.Fitnesses)
+	runStats := multi.Stats[representative]
+	rate, rSquared := ga.FitConvergenceRate(multi.Convergences[representative], gaConfig.Minimize)
+
+	absoluteError := optimum - multi.BestFitness
+	relativeError := absoluteError / optimum
+
+	result := ExperimentResult{
+		TaskName:          taskName,
+		Config:            config,
+		BestFitness:       multi.BestFitness,
+		MeanFitness:       multi.MeanFitness,
+		StdDevFitness:     multi.StdDev,
+		ExecutionTime:     durationToMs(multi.TotalTime) / float64(multi.Runs()),
+		AbsoluteError:     absoluteError,
+		RelativeError:     relativeError,
+		Convergence:       multi.Convergences[representative],
+		MeanConvergence:   runStats.MeanFitness,
+		StdDevConvergence: runStats.StdDevFitness,
+		NormalizedFitness: normalizeFitness(multi.BestFitness, optimum, worst),
+		Seeds:             multi.Seeds,
+		Repetitions:       runs,
+		FailedRuns:        len(multi.Failures),
+
+		FitnessEvaluations: multi.MeanEvaluations(),
+		RunFitnessValues:   multi.Fitnesses,
+
+		SelectionDifferential: runStats.SelectionDifferential,
+		SelectionResponse:     runStats.SelectionResponse,
+		DistinctIndividuals:   runStats.FinalDistinct,
+		DistinctPerGeneration: runStats.DistinctIndividuals,
+		Entropy:               runStats.Entropy,
+		ConvergenceRate:       rate,
+		ConvergenceRateR2:     rSquared,
+	}
+	terminations := slices.Clone(multi.Terminations)
+	for _, failure := range multi.Failures {
+		terminations = append(terminations, failure.Termination)
+	}
+	result.TerminationReason, result.TerminationCounts = summarizeTerminations(terminations)
 
-		bestFitness := fitnessValues[0]
-		for _, f := range fitnessValues {
-			if f > bestFitness {
-				bestFitness = f
-			}
-		}
+	return result, true
+}
 
-		absoluteError := linearBest - bestFitness
-		relativeError := absoluteError / linearBest
-
-		result := ExperimentResult{
-			TaskName:      "array_search",
-			Config:        config,
-			BestFitness:   bestFitness,
-			MeanFitness:   meanFitness,
-			StdDevFitness: stdDev,
-			ExecutionTime: float64(totalTime.Milliseconds()) / float64(runs),
-			AbsoluteError: absoluteError,
-			RelativeError: relativeError,
-			Convergence:   convergence,
-		}
+// Добавляет повторы, пока доверительный интервал шире TargetCIWidth.
+func (er *ExperimentRunner) extendRepetitions(config ga.Config, multi *ga.MultiRunResult, seedFor func(run int) int64) {
+	if er.TargetCIWidth <= 0 {
+		return
+	}
 
-		results = append(results, result)
+	maxRuns := er.MaxRepetitions
+	if maxRuns <= 0 {
+		maxRuns = defaultMaxRepetitions
 	}
 
-	return results
+	for multi.Runs() < maxRuns && meanCIWidth(multi.Fitnesses) > er.TargetCIWidth {
+		seed := seedFor(multi.Runs())
+		multi.Merge(ga.RunMany(config, 1, []int64{seed}))
+	}
 }
 
-func (er *ExperimentRunner) runGAForFunction(linearBest float64) []ExperimentResult {
-	results := make([]ExperimentResult, 0)
+// Зерно повтора run конфигурации configIndex задачи taskName.
+func (er *ExperimentRunner) runSeed(taskName string, configIndex, run int) int64 {
+	if er.BaseSeed == 0 {
+		return time.Now().UnixNano() + int64(run)
+	}
 
-	configs := er.generateConfigs()
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%d/%s/%d/%d", er.BaseSeed, taskName, configIndex, run)
+	return int64(h.Sum64())
+}
 
-	configNum := 0
-	totalConfigs := len(configs)
+// Ширина 95%-го доверительного интервала среднего в нормальном
+// приближении (по выборочному стандартному отклонению).
+func meanCIWidth(values []float64) float64 {
+	n := len(values)
+	if n < 2 {
+		return math.Inf(1)
+	}
 
-	for _, config := range configs {
-		configNum++
-		if configNum%10 == 0 {
-			fmt.Printf("Прогресс: %d/%d конфигураций\n", configNum, totalConfigs)
-		}
+	mean := 0.0
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(n)
 
-		runs := 5
-		fitnessValues := make([]float64, runs)
-		var totalTime time.Duration
-		var convergence []float64
-
-		for run := 0; run < runs; run++ {
-			gaConfig := ga.Config{
-				PopulationSize: config.PopulationSize,
-				MaxGenerations: config.MaxGenerations,
-				CrossoverProb:  config.CrossoverProb,
-				MutationProb:   config.MutationProb,
-				CrossoverType:  config.CrossoverType,
-				ElitismCount:   config.ElitismCount,
-				BitsPerGene:    16,
-				FitnessFunc:    er.functionFitnessFunc(),
-				Seed:           int64(time.Now().UnixNano() + int64(run)),
-			}
+	sum := 0.0
+	for _, v := range values {
+		sum += (v - mean) * (v - mean)
+	}
+	sampleStdDev := math.Sqrt(sum / float64(n-1))
+	return 2 * 1.96 * sampleStdDev / math.Sqrt(float64(n))
+}
 
-			algorithm := ga.NewGeneticAlgorithm(gaConfig)
+func (er *ExperimentRunner) gaConfig(taskName string, config ExperimentConfig, seed int64) ga.Config {
+	gaConfig := ga.Config{
+		PopulationSize: config.PopulationSize,
+		MaxGenerations: config.MaxGenerations,
+		CrossoverProb:  config.CrossoverProb,
+		MutationProb:   config.MutationProb,
+		CrossoverType:  config.CrossoverType,
+		ElitismCount:   config.ElitismCount,
+		Seed:           seed,
+		RandSource:     er.RandSource,
+		MaxDuration:    er.MaxRunDuration,
+	}
 
-			start := time.Now()
-			best, conv := algorithm.Run()
-			elapsed := time.Since(start)
+	gaConfig.Encoding = er.Encoding
+	if taskName == "array_search" {
+		gaConfig.BitsPerGene = er.arrayBitsPerGene()
+		gaConfig.FitnessFunc = er.arrayFitnessFunc(er.Encoding)
+	} else {
+		gaConfig.BitsPerGene = functionBitsPerGene
+		gaConfig.FitnessFunc = er.functionFitnessFunc(er.Encoding)
+		gaConfig.TwoPhase = er.TwoPhase
+		gaConfig.PhaseSplit = er.PhaseSplit
+		gaConfig.DecodeMin, gaConfig.DecodeMax = er.domain()
+		gaConfig.RealFitnessFunc = er.targetFunction
+	}
 
-			fitnessValues[run] = best.Fitness
-			totalTime += elapsed
-			if run == 0 {
-				convergence = conv
-			}
-		}
+	return gaConfig
+}
 
-		meanFitness := 0.0
-		for _, f := range fitnessValues {
-			meanFitness += f
+// Повторяет запуск run из сохранённого результата с тем же зерном.
+// Массив array_search готовится так же, как в RunAllExperiments
+// (генерация или CSV, затем прореживание по MaxArrayElements), поэтому
+// раннер должен быть настроен так же, как исходный.
+func (er *ExperimentRunner) Replay(result ExperimentResult, run int) (ga.Individual, []float64, error) {
+	if run < 0 || run >= len(result.Seeds) {
+		return ga.Individual{}, nil, fmt.Errorf("нет зерна для повтора %d (сохранено %d)", run, len(result.Seeds))
+	}
+	if result.TaskName == "array_search" {
+		if err := er.prepareArray(); err != nil {
+			return ga.Individual{}, nil, err
 		}
-		meanFitness /= float64(runs)
+	}
 
-		stdDev := ga.StdDev(fitnessValues, meanFitness)
+	algorithm, err := ga.NewGeneticAlgorithmChecked(er.gaConfig(result.TaskName, result.Config, result.Seeds[run]))
+	if err != nil {
+		return ga.Individual{}, nil, err
+	}
+	best, convergence := algorithm.Run()
+	return best, convergence, nil
+}
 
-		bestFitness := fitnessValues[0]
-		for _, f := range fitnessValues {
-			if f > bestFitness {
-				bestFitness = f
-			}
+// При равенстве частот выбирается причина, встретившаяся раньше.
+func summarizeTerminations(reasons []string) (string, map[string]int) {
+	counts := make(map[string]int)
+	mostCommon := ""
+	for _, reason := range reasons {
+		counts[reason]++
+		if mostCommon == "" || counts[reason] > counts[mostCommon] {
+			mostCommon = reason
 		}
+	}
+	return mostCommon, counts
+}
 
-		absoluteError := linearBest - bestFitness
-		relativeError := absoluteError / linearBest
-
-		result := ExperimentResult{
-			TaskName:      "function_optimization",
-			Config:        config,
-			BestFitness:   bestFitness,
-			MeanFitness:   meanFitness,
-			StdDevFitness: stdDev,
-			ExecutionTime: float64(totalTime.Milliseconds()) / float64(runs),
-			AbsoluteError: absoluteError,
-			RelativeError: relativeError,
-			Convergence:   convergence,
+func (er *ExperimentRunner) representativeRun(fitnessValues []float64) int {
+	switch er.ConvergencePolicy {
+	case "first":
+		return 0
+	case "best":
+		best := 0
+		for i, f := range fitnessValues {
+			if f > fitnessValues[best] {
+				best = i
+			}
 		}
+		return best
+	}
 
-		results = append(results, result)
+	order := make([]int, len(fitnessValues))
+	for i := range order {
+		order[i] = i
 	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return fitnessValues[order[i]] < fitnessValues[order[j]]
+	})
+	return order[(len(order)-1)/2]
+}
 
-	return results
+// Миллисекунды с дробной частью: Milliseconds() обрезает быстрые запуски до нуля.
+func durationToMs(d time.Duration) float64 {
+	return float64(d.Nanoseconds()) / 1e6
+}
+
+func (er *ExperimentRunner) optimumFor(linear LinearSearchResult) float64 {
+	if optimum, ok := er.KnownOptimum[linear.TaskName]; ok {
+		return optimum
+	}
+	if linear.TaskName == "array_search" && er.plantedIndex >= 0 {
+		return math.Max(er.PlantedOptimumValue, linear.BestValue)
+	}
+	return linear.BestValue
+}
+
+// Оптимум — известный или найденный линейным поиском, худшее — минимум задачи.
+func normalizeFitness(value, optimum, worst float64) float64 {
+	if optimum == worst {
+		return 1
+	}
+	return (value - worst) / (optimum - worst)
 }
 
-func (er *ExperimentRunner) arrayFitnessFunc() func([]byte) float64 {
+func (er *ExperimentRunner) arrayFitnessFunc(encoding string) func([]byte) float64 {
 	return func(genes []byte) float64 {
-		index := ga.BytesToInt(genes) % len(er.arrayData)
+		index := ga.DecodeIndex(genes, len(er.arrayData), encoding)
 		return er.arrayData[index]
 	}
 }
 
-func (er *ExperimentRunner) functionFitnessFunc() func([]byte) float64 {
+func (er *ExperimentRunner) functionFitnessFunc(encoding string) func([]byte) float64 {
+	min, max := er.domain()
 	return func(genes []byte) float64 {
-		x := ga.BytesToFloat(genes, 2.7, 7.5)
+		x := ga.DecodeFloat(genes, min, max, encoding)
 		return er.targetFunction(x)
 	}
 }
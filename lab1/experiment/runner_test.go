@@ -0,0 +1,281 @@
+package experiment
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"lab1/ga"
+)
+
+func smallGrid() ParamGrid {
+	return ParamGrid{
+		PopulationSizes: []int{10, 20},
+		MaxGenerations:  []int{5, 10},
+		CrossoverProbs:  []float64{0.8},
+		MutationProbs:   []float64{0.05, 0.1},
+		CrossoverTypes:  []string{"onepoint", "uniform"},
+		ElitismCounts:   []int{1},
+	}
+}
+
+// Раннер с небольшим массивом, фиксированным зерном и без вывода прогресса.
+func newSmallRunner(parallelism int) *ExperimentRunner {
+	runner := NewExperimentRunner(smallGrid())
+	runner.ArraySize = 2000
+	runner.BaseSeed = 42
+	runner.Parallelism = parallelism
+	runner.SetProgressReporter(nil)
+	return runner
+}
+
+// Обнуляет измеренное время: единственное, что зависит от планирования.
+func withoutTimings(results *AllResults) *AllResults {
+	for i := range results.LinearSearchResults {
+		results.LinearSearchResults[i].ExecutionTime = 0
+	}
+	for i := range results.GAResults {
+		results.GAResults[i].ExecutionTime = 0
+	}
+	for i := range results.SimulatedAnnealingResults {
+		results.SimulatedAnnealingResults[i].ExecutionTime = 0
+	}
+	return results
+}
+
+func TestParallelRunMatchesSerial(t *testing.T) {
+	serial, err := newSmallRunner(1).RunAllExperiments()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parallel, err := newSmallRunner(4).RunAllExperiments()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := 2 * 16; len(serial.GAResults) != want {
+		t.Fatalf("последовательный запуск дал %d результатов, ожидалось %d", len(serial.GAResults), want)
+	}
+	if !reflect.DeepEqual(withoutTimings(serial), withoutTimings(parallel)) {
+		t.Fatal("результаты параллельного запуска отличаются от последовательного")
+	}
+}
+
+// Повторы, завершившиеся ошибкой, не входят в статистику конфигурации;
+// если ошибкой завершились все, конфигурация пропускается.
+func TestRunConfigExcludesFailedRuns(t *testing.T) {
+	runner := newSmallRunner(1)
+	config := ExperimentConfig{
+		PopulationSize: 10, MaxGenerations: 5, CrossoverProb: 0.8,
+		MutationProb: 0.1, CrossoverType: "uniform", ElitismCount: 1,
+	}
+
+	runner.SetTargetFunction(func(float64) float64 { return math.NaN() }, [2]float64{0, 1})
+	if _, ok := runner.runConfig("function_optimization", 0, config, 1, 0); ok {
+		t.Fatal("конфигурация, все повторы которой завершились ошибкой, не пропущена")
+	}
+
+	runner.SetTargetFunction(func(x float64) float64 {
+		if x > 0.97 {
+			return math.NaN()
+		}
+		return x
+	}, [2]float64{0, 1})
+	var partial *ExperimentResult
+	for i := 0; i < 20 && partial == nil; i++ {
+		result, ok := runner.runConfig("function_optimization", i, config, 1, 0)
+		if ok && result.FailedRuns > 0 {
+			partial = &result
+		}
+	}
+	if partial == nil {
+		t.Fatal("не нашлось конфигурации с частично неудачными повторами")
+	}
+	if len(partial.Seeds) != partial.Repetitions || len(partial.RunFitnessValues) != partial.Repetitions {
+		t.Fatalf("Seeds %d, RunFitnessValues %d, Repetitions %d", len(partial.Seeds), len(partial.RunFitnessValues), partial.Repetitions)
+	}
+	for _, f := range partial.RunFitnessValues {
+		if math.IsNaN(f) {
+			t.Fatal("в RunFitnessValues попал неудачный повтор")
+		}
+	}
+	if math.IsNaN(partial.MeanFitness) || math.IsNaN(partial.BestFitness) {
+		t.Fatalf("сводка содержит NaN: %+v", partial)
+	}
+	if partial.TerminationCounts["invalid_fitness"] != partial.FailedRuns {
+		t.Fatalf("TerminationCounts = %v при %d неудачных повторах", partial.TerminationCounts, partial.FailedRuns)
+	}
+}
+
+// NaN и бесконечности в CSV отклоняются с номером строки, как и нечисла.
+func TestLoadArrayFromCSVRejectsNonFinite(t *testing.T) {
+	tests := []struct {
+		name, content, want string
+	}{
+		{"NaN", "1\n2, NaN\n", "строка 2"},
+		{"Inf", "+Inf\n", "строка 1"},
+		{"-inf", "1\n2\n3,-inf", "строка 3"},
+		{"переполнение", "1e400", "строка 1"},
+		{"не число", "1\nabc", "строка 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "array.csv")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			err := newSmallRunner(1).LoadArrayFromCSV(path)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("ошибка %v, ожидалась с %q", err, tt.want)
+			}
+		})
+	}
+
+	path := filepath.Join(t.TempDir(), "array.csv")
+	if err := os.WriteFile(path, []byte("1, -2.5\n1e3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := newSmallRunner(1).LoadArrayFromCSV(path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Кривые сходимости результата — лучшая, средняя и отклонение — относятся
+// к одному запуску и содержат по значению на каждое поколение.
+func TestConvergenceCurvesHaveEqualLengths(t *testing.T) {
+	runner := newSmallRunner(1)
+	results, err := runner.RunAllExperiments()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, result := range results.GAResults {
+		n := len(result.Convergence)
+		if n != result.Config.MaxGenerations ||
+			len(result.MeanConvergence) != n || len(result.StdDevConvergence) != n {
+			t.Fatalf("%s %+v: длины кривых %d, %d, %d при %d поколениях",
+				result.TaskName, result.Config, n, len(result.MeanConvergence),
+				len(result.StdDevConvergence), result.Config.MaxGenerations)
+		}
+	}
+}
+
+// Функция и область, заданные SetTargetFunction, используются и линейным
+// поиском, и ГА, и отжигом: 10 - (x-1.5)² на [0, 4] с максимумом 10 и
+// минимумом 3.75 (стандартная функция не поднимается выше 0.89).
+func TestCustomTargetFunctionFlowsThrough(t *testing.T) {
+	var (
+		mu         sync.Mutex
+		calls      int
+		minX, maxX = math.Inf(1), math.Inf(-1)
+	)
+	runner := newSmallRunner(2)
+	runner.AnnealingIterations = 500
+	runner.SetTargetFunction(func(x float64) float64 {
+		mu.Lock()
+		calls++
+		minX, maxX = math.Min(minX, x), math.Max(maxX, x)
+		mu.Unlock()
+		return 10 - (x-1.5)*(x-1.5)
+	}, [2]float64{0, 4})
+
+	results, err := runner.RunAllExperiments()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls == 0 || minX < 0 || maxX > 4 {
+		t.Fatalf("функция вызвана %d раз на [%v, %v], ожидались вызовы внутри [0, 4]", calls, minX, maxX)
+	}
+
+	for _, linear := range results.LinearSearchResults {
+		if linear.TaskName != "function_optimization" {
+			continue
+		}
+		if math.Abs(linear.BestValue-10) > 1e-9 || math.Abs(linear.WorstValue-3.75) > 1e-9 {
+			t.Fatalf("линейный поиск: максимум %v, минимум %v; ожидалось 10 и 3.75", linear.BestValue, linear.WorstValue)
+		}
+	}
+	for _, sa := range results.SimulatedAnnealingResults {
+		if sa.TaskName == "function_optimization" && (sa.BestValue < 9 || sa.BestValue > 10) {
+			t.Fatalf("отжиг нашёл %v, ожидалось значение около 10", sa.BestValue)
+		}
+	}
+	found := 0
+	for _, result := range results.GAResults {
+		if result.TaskName != "function_optimization" {
+			continue
+		}
+		found++
+		if result.BestFitness < 9 || result.BestFitness > 10 || result.AbsoluteError != 10-result.BestFitness {
+			t.Fatalf("ГА %+v: лучшая %v, ошибка %v; ожидалось значение около 10 и ошибка от оптимума 10",
+				result.Config, result.BestFitness, result.AbsoluteError)
+		}
+	}
+	if found == 0 {
+		t.Fatal("нет результатов ГА для задачи оптимизации функции")
+	}
+}
+
+// Небольшой равномерный массив: значения лежат в [mean-√3σ, mean+√3σ],
+// длина хромосомы выводится из размера массива, и перебор всех генотипов
+// через функцию приспособленности ГА достаёт каждый элемент.
+func TestSmallUniformArrayIsFullyCovered(t *testing.T) {
+	for _, size := range []int{1, 2, 37, 64, 65} {
+		runner := newSmallRunner(1)
+		runner.ArraySize = size
+		runner.Distribution = "uniform"
+		runner.ArrayMean, runner.ArrayStdDev = 10, 2
+		if err := runner.prepareArray(); err != nil {
+			t.Fatal(err)
+		}
+		if len(runner.arrayData) != size {
+			t.Fatalf("сгенерировано %d элементов, ожидалось %d", len(runner.arrayData), size)
+		}
+		halfWidth := math.Sqrt(3) * 2
+		position := make(map[float64]int, size)
+		for i, v := range runner.arrayData {
+			if v < 10-halfWidth || v > 10+halfWidth {
+				t.Fatalf("размер %d: элемент %d = %v вне [%v, %v]", size, i, v, 10-halfWidth, 10+halfWidth)
+			}
+			position[v] = i
+		}
+
+		bits := runner.arrayBitsPerGene()
+		if 1<<bits < size || (bits > 1 && 1<<(bits-1) >= size) {
+			t.Fatalf("размер %d: %d бит — не наименьшая длина, покрывающая массив", size, bits)
+		}
+		for _, encoding := range []string{"binary", "gray"} {
+			fitness := runner.arrayFitnessFunc(encoding)
+			reached := make(map[int]bool, size)
+			for value := 0; value < 1<<bits; value++ {
+				genes := ga.SignedIntToBytes(int64(value), bits)
+				reached[position[fitness(genes)]] = true
+			}
+			if len(reached) != size {
+				t.Fatalf("размер %d, %s: достижимо %d элементов из %d", size, encoding, len(reached), size)
+			}
+		}
+	}
+}
+
+// MaxRunDuration применяется ко всем запускам ГА: при исчерпанном бюджете
+// каждый повтор останавливается после первого поколения.
+func TestMaxRunDurationAppliesToEveryRun(t *testing.T) {
+	runner := newSmallRunner(2)
+	runner.MaxRunDuration = time.Nanosecond
+	results, err := runner.RunAllExperiments()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, result := range results.GAResults {
+		if result.TerminationReason != ga.TerminationTimeBudget ||
+			result.TerminationCounts[ga.TerminationTimeBudget] != result.Repetitions || len(result.Convergence) != 1 {
+			t.Fatalf("%s %+v: причина %q, разбивка %v, %d поколений", result.TaskName, result.Config,
+				result.TerminationReason, result.TerminationCounts, len(result.Convergence))
+		}
+	}
+}
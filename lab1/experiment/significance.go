@@ -0,0 +1,142 @@
+package experiment
+
+import (
+	"fmt"
+	"math"
+)
+
+// t-критерий Уэлча для двух независимых выборок с разными дисперсиями:
+// статистика t = (mean(a) - mean(b)) / sqrt(var(a)/n_a + var(b)/n_b)
+// (дисперсии выборочные) и двустороннее p-значение по распределению
+// Стьюдента со степенями свободы Уэлча–Саттертуэйта. Если в одной из
+// выборок меньше двух значений, оба результата — NaN.
+func WelchTTest(a, b []float64) (tStat, pValue float64) {
+	na, nb := float64(len(a)), float64(len(b))
+	if na < 2 || nb < 2 {
+		return math.NaN(), math.NaN()
+	}
+
+	meanA, varA := sampleMeanVar(a)
+	meanB, varB := sampleMeanVar(b)
+	sa, sb := varA/na, varB/nb
+	if sa+sb == 0 {
+		if meanA == meanB {
+			return 0, 1
+		}
+		return math.Copysign(math.Inf(1), meanA-meanB), 0
+	}
+
+	tStat = (meanA - meanB) / math.Sqrt(sa+sb)
+	df := welchDF(sa, sb, na, nb)
+	pValue = regularizedIncompleteBeta(df/(df+tStat*tStat), df/2, 0.5)
+	return tStat, pValue
+}
+
+// Степени свободы Уэлча–Саттертуэйта; sa и sb — дисперсии, делённые на
+// размеры выборок na и nb.
+func welchDF(sa, sb, na, nb float64) float64 {
+	return (sa + sb) * (sa + sb) / (sa*sa/(na-1) + sb*sb/(nb-1))
+}
+
+func sampleMeanVar(values []float64) (mean, variance float64) {
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, variance / float64(len(values)-1)
+}
+
+// Итог сравнения двух конфигураций по итоговой приспособленности повторов.
+type Comparison struct {
+	MeanA, MeanB float64
+	TStat        float64
+	PValue       float64
+	Alpha        float64
+	// Разница средних значима на уровне Alpha (PValue < Alpha).
+	Significant bool
+}
+
+// Сравнивает две конфигурации t-критерием Уэлча по RunFitnessValues.
+func CompareResults(a, b ExperimentResult, alpha float64) (Comparison, error) {
+	if alpha <= 0 || alpha >= 1 {
+		return Comparison{}, fmt.Errorf("уровень значимости должен быть в (0, 1), получено %v", alpha)
+	}
+	if len(a.RunFitnessValues) < 2 || len(b.RunFitnessValues) < 2 {
+		return Comparison{}, fmt.Errorf("для сравнения нужно не меньше двух повторов каждой конфигурации (есть %d и %d)",
+			len(a.RunFitnessValues), len(b.RunFitnessValues))
+	}
+
+	t, p := WelchTTest(a.RunFitnessValues, b.RunFitnessValues)
+	meanA, _ := sampleMeanVar(a.RunFitnessValues)
+	meanB, _ := sampleMeanVar(b.RunFitnessValues)
+	return Comparison{
+		MeanA:       meanA,
+		MeanB:       meanB,
+		TStat:       t,
+		PValue:      p,
+		Alpha:       alpha,
+		Significant: p < alpha,
+	}, nil
+}
+
+// Регуляризованная неполная бета-функция I_x(a, b) через цепную дробь
+// (метод Лентца); для x > (a+1)/(a+b+2) используется симметрия
+// I_x(a, b) = 1 - I_{1-x}(b, a), при которой дробь сходится быстро.
+func regularizedIncompleteBeta(x, a, b float64) float64 {
+	switch {
+	case x <= 0:
+		return 0
+	case x >= 1:
+		return 1
+	case x > (a+1)/(a+b+2):
+		return 1 - regularizedIncompleteBeta(1-x, b, a)
+	}
+
+	lgA, _ := math.Lgamma(a)
+	lgB, _ := math.Lgamma(b)
+	lgAB, _ := math.Lgamma(a + b)
+	front := math.Exp(lgAB - lgA - lgB + a*math.Log(x) + b*math.Log(1-x))
+	return front * betaContinuedFraction(x, a, b) / a
+}
+
+func betaContinuedFraction(x, a, b float64) float64 {
+	const (
+		maxIterations = 300
+		epsilon       = 1e-15
+		tiny          = 1e-300
+	)
+
+	clamp := func(v float64) float64 {
+		if math.Abs(v) < tiny {
+			return tiny
+		}
+		return v
+	}
+
+	c, d := 1.0, 1/clamp(1-(a+b)*x/(a+1))
+	result := d
+	for m := 1; m <= maxIterations; m++ {
+		fm := float64(m)
+
+		// Чётный шаг.
+		num := fm * (b - fm) * x / ((a + 2*fm - 1) * (a + 2*fm))
+		d = 1 / clamp(1+num*d)
+		c = clamp(1 + num/c)
+		result *= d * c
+
+		// Нечётный шаг.
+		num = -(a + fm) * (a + b + fm) * x / ((a + 2*fm) * (a + 2*fm + 1))
+		d = 1 / clamp(1+num*d)
+		c = clamp(1 + num/c)
+		delta := d * c
+		result *= delta
+
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+	return result
+}
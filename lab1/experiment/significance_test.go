@@ -0,0 +1,74 @@
+package experiment
+
+import (
+	"math"
+	"testing"
+)
+
+// Выборки — примеры из статьи «Welch's t-test» в Википедии; эталонные t,
+// степени свободы и p округлены до двух, одного и трёх знаков.
+func TestWelchTTestReferenceValues(t *testing.T) {
+	cases := []struct {
+		name     string
+		a, b     []float64
+		t, df, p float64
+	}{
+		{
+			name: "равные размеры",
+			a:    []float64{27.5, 21.0, 19.0, 23.6, 17.0, 17.9, 16.9, 20.1, 21.9, 22.6, 23.1, 19.6, 19.0, 21.7, 21.4},
+			b:    []float64{27.1, 22.0, 20.8, 23.4, 23.4, 23.5, 25.8, 22.0, 24.8, 20.2, 21.9, 22.1, 22.9, 20.5, 24.4},
+			t:    -2.46, df: 25.0, p: 0.021,
+		},
+		{
+			name: "меньшая выборка с большей дисперсией",
+			a:    []float64{17.2, 20.9, 22.6, 18.1, 21.7, 21.4, 23.5, 24.2, 14.7, 21.8},
+			b: []float64{21.5, 22.8, 21.0, 23.0, 21.6, 23.6, 22.5, 20.7, 23.4, 21.8,
+				20.7, 21.7, 21.5, 22.5, 23.6, 21.5, 22.5, 23.5, 21.5, 21.8},
+			t: -1.57, df: 9.9, p: 0.149,
+		},
+		{
+			name: "большая выборка с большей дисперсией",
+			a:    []float64{19.8, 20.4, 19.6, 17.8, 18.5, 18.9, 18.3, 18.9, 19.5, 22.0},
+			b: []float64{28.2, 26.6, 20.1, 23.3, 25.2, 22.1, 17.7, 27.6, 20.6, 13.7,
+				23.2, 17.5, 20.6, 18.0, 23.9, 21.6, 24.3, 20.4, 23.9, 13.3},
+			t: -2.23, df: 24.5, p: 0.035,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tStat, p := WelchTTest(tc.a, tc.b)
+			if math.Abs(tStat-tc.t) > 0.005 {
+				t.Errorf("t = %.4f, ожидалось %.2f", tStat, tc.t)
+			}
+			if math.Abs(p-tc.p) > 0.0005 {
+				t.Errorf("p = %.4f, ожидалось %.3f", p, tc.p)
+			}
+
+			// Перестановка выборок меняет только знак t.
+			tSwapped, pSwapped := WelchTTest(tc.b, tc.a)
+			if tSwapped != -tStat || math.Abs(pSwapped-p) > 1e-12 {
+				t.Errorf("перестановка выборок: t = %v, p = %v; ожидалось %v, %v", tSwapped, pSwapped, -tStat, p)
+			}
+
+			_, varA := sampleMeanVar(tc.a)
+			_, varB := sampleMeanVar(tc.b)
+			na, nb := float64(len(tc.a)), float64(len(tc.b))
+			if df := welchDF(varA/na, varB/nb, na, nb); math.Abs(df-tc.df) > 0.05 {
+				t.Errorf("степеней свободы %.3f, ожидалось %.1f", df, tc.df)
+			}
+		})
+	}
+}
+
+func TestWelchTTestDegenerateSamples(t *testing.T) {
+	if tStat, p := WelchTTest([]float64{1}, []float64{1, 2}); !math.IsNaN(tStat) || !math.IsNaN(p) {
+		t.Errorf("для выборки из одного значения получено t = %v, p = %v, ожидалось NaN", tStat, p)
+	}
+	if tStat, p := WelchTTest([]float64{3, 3}, []float64{3, 3, 3}); tStat != 0 || p != 1 {
+		t.Errorf("равные константные выборки: t = %v, p = %v, ожидалось 0 и 1", tStat, p)
+	}
+	if tStat, p := WelchTTest([]float64{1, 1}, []float64{2, 2}); !math.IsInf(tStat, -1) || p != 0 {
+		t.Errorf("разные константные выборки: t = %v, p = %v, ожидалось -Inf и 0", tStat, p)
+	}
+}
@@ -0,0 +1,124 @@
+package experiment
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+
+	// Чистый Go-драйвер без cgo, регистрируется под именем sqliteDriver.
+	_ "modernc.org/sqlite"
+)
+
+const sqliteDriver = "sqlite"
+
+var sqliteSchema = []string{
+	`CREATE TABLE configs (
+		id INTEGER PRIMARY KEY,
+		population_size INTEGER NOT NULL,
+		max_generations INTEGER NOT NULL,
+		crossover_prob REAL NOT NULL,
+		mutation_prob REAL NOT NULL,
+		crossover_type TEXT NOT NULL,
+		elitism_count INTEGER NOT NULL
+	)`,
+	`CREATE TABLE results (
+		id INTEGER PRIMARY KEY,
+		config_id INTEGER NOT NULL REFERENCES configs(id),
+		task_name TEXT NOT NULL,
+		best_fitness REAL NOT NULL,
+		mean_fitness REAL NOT NULL,
+		std_dev_fitness REAL NOT NULL,
+		execution_time_ms REAL NOT NULL,
+		absolute_error REAL NOT NULL,
+		relative_error REAL NOT NULL,
+		normalized_fitness REAL NOT NULL,
+		repetitions INTEGER NOT NULL,
+		fitness_evaluations REAL NOT NULL,
+		termination_reason TEXT NOT NULL
+	)`,
+	`CREATE TABLE convergence (
+		result_id INTEGER NOT NULL REFERENCES results(id),
+		generation INTEGER NOT NULL,
+		fitness REAL NOT NULL,
+		PRIMARY KEY (result_id, generation)
+	)`,
+	`CREATE TABLE linear_search (
+		task_name TEXT PRIMARY KEY,
+		best_value REAL NOT NULL,
+		worst_value REAL NOT NULL,
+		execution_time_ms REAL NOT NULL,
+		fitness_evaluations INTEGER NOT NULL
+	)`,
+	// Плоское представление для запросов вида
+	// SELECT * FROM ga_results WHERE mutation_prob = 0.05 ORDER BY best_fitness DESC.
+	`CREATE VIEW ga_results AS
+		SELECT r.*, c.population_size, c.max_generations, c.crossover_prob,
+			c.mutation_prob, c.crossover_type, c.elitism_count
+		FROM results r JOIN configs c ON c.id = r.config_id`,
+}
+
+// Сохраняет результаты в новую базу SQLite (существующий файл
+// перезаписывается): таблицы configs, results, convergence, linear_search
+// и представление ga_results.
+func (ar *AllResults) SaveToSQLite(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	db, err := sql.Open(sqliteDriver, path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, statement := range sqliteSchema {
+		if _, err := tx.Exec(statement); err != nil {
+			return fmt.Errorf("создание схемы: %w", err)
+		}
+	}
+
+	for _, r := range ar.LinearSearchResults {
+		if _, err := tx.Exec(`INSERT INTO linear_search VALUES (?, ?, ?, ?, ?)`,
+			r.TaskName, r.BestValue, r.WorstValue, r.ExecutionTime, r.FitnessEvaluations); err != nil {
+			return err
+		}
+	}
+
+	configIDs := make(map[ExperimentConfig]int64)
+	for i, r := range ar.GAResults {
+		configID, ok := configIDs[r.Config]
+		if !ok {
+			configID = int64(len(configIDs) + 1)
+			c := r.Config
+			if _, err := tx.Exec(`INSERT INTO configs VALUES (?, ?, ?, ?, ?, ?, ?)`,
+				configID, c.PopulationSize, c.MaxGenerations, c.CrossoverProb,
+				c.MutationProb, c.CrossoverType, c.ElitismCount); err != nil {
+				return err
+			}
+			configIDs[r.Config] = configID
+		}
+
+		resultID := int64(i + 1)
+		if _, err := tx.Exec(`INSERT INTO results VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			resultID, configID, r.TaskName, r.BestFitness, r.MeanFitness, r.StdDevFitness,
+			r.ExecutionTime, r.AbsoluteError, r.RelativeError, r.NormalizedFitness,
+			r.Repetitions, r.FitnessEvaluations, r.TerminationReason); err != nil {
+			return err
+		}
+
+		for generation, fitness := range r.Convergence {
+			if _, err := tx.Exec(`INSERT INTO convergence VALUES (?, ?, ?)`,
+				resultID, generation, fitness); err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,74 @@
+package experiment
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+)
+
+// Записанное в SQLite читается обратно: число строк и значения
+// совпадают с исходными результатами.
+func TestSaveToSQLiteReadBack(t *testing.T) {
+	results, err := newSmallRunner(1).RunAllExperiments()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "results.db")
+	if err := results.SaveToSQLite(path); err != nil {
+		t.Fatal(err)
+	}
+	// Повторное сохранение перезаписывает файл, а не дописывает в него.
+	if err := results.SaveToSQLite(path); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open(sqliteDriver, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM ga_results`).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != len(results.GAResults) {
+		t.Fatalf("в ga_results %d строк, ожидалось %d", count, len(results.GAResults))
+	}
+
+	want := results.GAResults[len(results.GAResults)-1]
+	var (
+		task              string
+		best              float64
+		populationSize    int
+		crossoverType     string
+		terminationReason string
+	)
+	err = db.QueryRow(`SELECT task_name, best_fitness, population_size, crossover_type, termination_reason
+		FROM ga_results WHERE id = ?`, len(results.GAResults)).
+		Scan(&task, &best, &populationSize, &crossoverType, &terminationReason)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task != want.TaskName || best != want.BestFitness || populationSize != want.Config.PopulationSize ||
+		crossoverType != want.Config.CrossoverType || terminationReason != want.TerminationReason {
+		t.Fatalf("прочитано (%s, %v, %d, %s, %s), ожидалось (%s, %v, %d, %s, %s)",
+			task, best, populationSize, crossoverType, terminationReason,
+			want.TaskName, want.BestFitness, want.Config.PopulationSize, want.Config.CrossoverType, want.TerminationReason)
+	}
+
+	var points int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM convergence WHERE result_id = ?`, len(results.GAResults)).Scan(&points); err != nil {
+		t.Fatal(err)
+	}
+	if points != len(want.Convergence) {
+		t.Fatalf("в convergence %d точек, ожидалось %d", points, len(want.Convergence))
+	}
+
+	if err := db.QueryRow(`SELECT COUNT(*) FROM linear_search`).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != len(results.LinearSearchResults) {
+		t.Fatalf("в linear_search %d строк, ожидалось %d", count, len(results.LinearSearchResults))
+	}
+}
@@ -0,0 +1,106 @@
+package experiment
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Пишет результаты конфигураций в файл по мере их получения, по одному
+// JSON-объекту на строку (NDJSON). Каждая запись сразу уходит в файл,
+// поэтому при аварийном завершении сохраняются все завершённые
+// конфигурации. Безопасен для вызова из нескольких горутин.
+type ResultStreamer struct {
+	mu      sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+	count   int
+	// Первая ошибка записи; последующие записи пропускаются, ошибку
+	// возвращает Close.
+	err error
+}
+
+func NewResultStreamer(filename string) (*ResultStreamer, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+	return &ResultStreamer{file: file, encoder: json.NewEncoder(file)}, nil
+}
+
+func (s *ResultStreamer) Write(result ExperimentResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	if err := s.encoder.Encode(result); err != nil {
+		s.err = fmt.Errorf("%s: запись результата %d: %w", s.file.Name(), s.count+1, err)
+		return s.err
+	}
+	s.count++
+	return nil
+}
+
+// Число записанных результатов.
+func (s *ResultStreamer) Count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.count
+}
+
+func (s *ResultStreamer) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return errors.Join(s.err, s.file.Close())
+}
+
+// Читает результаты, записанные ResultStreamer, в порядке записи.
+// Оборванная последняя строка (запись прервана аварийным завершением)
+// пропускается; повреждение в середине файла считается ошибкой.
+func LoadStreamedResults(filename string) ([]ExperimentResult, error) {
+	results := make([]ExperimentResult, 0)
+	err := ReadStreamedResults(filename, func(result ExperimentResult) error {
+		results = append(results, result)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Как LoadStreamedResults, но передаёт результаты в fn по одному, не
+// накапливая их; ошибка fn прерывает чтение и возвращается.
+func ReadStreamedResults(filename string, fn func(ExperimentResult) error) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// Без завершающего перевода строки запись не была дописана.
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var result ExperimentResult
+		if err := json.Unmarshal(data, &result); err != nil {
+			return fmt.Errorf("%s: строка %d: %w", filename, line, err)
+		}
+		if err := fn(result); err != nil {
+			return err
+		}
+	}
+}
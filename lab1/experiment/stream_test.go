@@ -0,0 +1,126 @@
+package experiment
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func streamedResult(i int) ExperimentResult {
+	return ExperimentResult{
+		TaskName:          "function_optimization",
+		Config:            ExperimentConfig{PopulationSize: 10 + i, MaxGenerations: 5, CrossoverProb: 0.8, MutationProb: 0.05, CrossoverType: "uniform", ElitismCount: 1},
+		BestFitness:       float64(i) / 7,
+		Convergence:       []float64{0, float64(i)},
+		Seeds:             []int64{int64(i)},
+		Repetitions:       1,
+		TerminationCounts: map[string]int{"max_generations": 1},
+	}
+}
+
+// Каждый результат читается из файла сразу после записи, до Close.
+func TestResultStreamerWritesIncrementally(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stream.ndjson")
+	streamer, err := NewResultStreamer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 50; i++ {
+		if err := streamer.Write(streamedResult(i)); err != nil {
+			t.Fatal(err)
+		}
+		read, err := LoadStreamedResults(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(read) != i+1 || streamer.Count() != i+1 {
+			t.Fatalf("после %d записей прочитано %d, Count = %d", i+1, len(read), streamer.Count())
+		}
+		if !reflect.DeepEqual(read[i], streamedResult(i)) {
+			t.Fatalf("результат %d прочитан как %+v", i, read[i])
+		}
+	}
+	if err := streamer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	count := 0
+	err = ReadStreamedResults(path, func(result ExperimentResult) error {
+		if !reflect.DeepEqual(result, streamedResult(count)) {
+			t.Fatalf("результат %d прочитан как %+v", count, result)
+		}
+		count++
+		return nil
+	})
+	if err != nil || count != 50 {
+		t.Fatalf("прочитано %d результатов, ошибка %v", count, err)
+	}
+
+	stop := errors.New("stop")
+	count = 0
+	err = ReadStreamedResults(path, func(ExperimentResult) error {
+		count++
+		return stop
+	})
+	if !errors.Is(err, stop) || count != 1 {
+		t.Fatalf("ошибка обработчика не прервала чтение: %d результатов, ошибка %v", count, err)
+	}
+}
+
+// Оборванная последняя запись пропускается.
+func TestLoadStreamedResultsSkipsTruncatedTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stream.ndjson")
+	streamer, err := NewResultStreamer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		streamer.Write(streamedResult(i))
+	}
+	if err := streamer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString(`{"task_name":"array_se`)
+	file.Close()
+
+	read, err := LoadStreamedResults(path)
+	if err != nil || len(read) != 3 {
+		t.Fatalf("прочитано %d результатов, ошибка %v", len(read), err)
+	}
+}
+
+// При потоковой записи раннер не держит результаты ГА в памяти, а поток
+// содержит те же результаты, что и обычный прогон.
+func TestRunnerStreamsInsteadOfBuffering(t *testing.T) {
+	buffered, err := newSmallRunner(1).RunAllExperiments()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runner := newSmallRunner(1)
+	runner.StreamFile = filepath.Join(t.TempDir(), "stream.ndjson")
+	streamed, err := runner.RunAllExperiments()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(streamed.GAResults) != 0 {
+		t.Fatalf("в памяти осталось %d результатов ГА", len(streamed.GAResults))
+	}
+
+	read, err := LoadStreamedResults(runner.StreamFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromStream := withoutTimings(&AllResults{GAResults: read}).GAResults
+	if !reflect.DeepEqual(fromStream, withoutTimings(buffered).GAResults) {
+		t.Fatalf("в потоке %d результатов, не совпадающих с обычным прогоном (%d)", len(fromStream), len(buffered.GAResults))
+	}
+}
@@ -0,0 +1,153 @@
+package ga
+
+import "math"
+
+// Подгоняет кривую сходимости моделью экспоненциального приближения к
+// пределу f(t) = f∞ - (f∞ - f0)·e^(-rate·t). За f∞ берётся итоговое лучшее
+// значение, кривая предварительно заменяется накопленным максимумом, так что
+// шумные и немонотонные истории не ломают логарифм. rSquared — качество
+// линейной регрессии ln(f∞ - f(t)) по t. Если точек с ненулевым отставанием
+// меньше двух, возвращается (0, 0). При minimize кривая минимизации
+// предварительно отражается (см. improvementCurve), так что скорость
+// положительна в обоих направлениях.
+func FitConvergenceRate(convergence []float64, minimize bool) (rate float64, rSquared float64) {
+	if len(convergence) < 2 {
+		return 0, 0
+	}
+	convergence = improvementCurve(convergence, minimize)
+
+	cumulative := make([]float64, len(convergence))
+	best := math.Inf(-1)
+	for i, v := range convergence {
+		if v > best {
+			best = v
+		}
+		cumulative[i] = best
+	}
+	limit := cumulative[len(cumulative)-1]
+
+	var xs, ys []float64
+	for t, v := range cumulative {
+		gap := limit - v
+		if gap <= 0 || math.IsNaN(gap) || math.IsInf(gap, 0) {
+			continue
+		}
+		xs = append(xs, float64(t))
+		ys = append(ys, math.Log(gap))
+	}
+	if len(xs) < 2 {
+		return 0, 0
+	}
+
+	slope, intercept := linearRegression(xs, ys)
+
+	var ssRes, ssTot float64
+	meanY := 0.0
+	for _, y := range ys {
+		meanY += y
+	}
+	meanY /= float64(len(ys))
+	for i := range xs {
+		predicted := intercept + slope*xs[i]
+		ssRes += (ys[i] - predicted) * (ys[i] - predicted)
+		ssTot += (ys[i] - meanY) * (ys[i] - meanY)
+	}
+
+	rSquared = 1
+	if ssTot > 0 {
+		rSquared = 1 - ssRes/ssTot
+	}
+	return -slope, rSquared
+}
+
+// Кривая, на которой улучшение — рост: при minimize — копия с обратным
+// знаком, иначе сама convergence.
+func improvementCurve(convergence []float64, minimize bool) []float64 {
+	if !minimize {
+		return convergence
+	}
+	negated := make([]float64, len(convergence))
+	for i, v := range convergence {
+		negated[i] = -v
+	}
+	return negated
+}
+
+func linearRegression(xs, ys []float64) (slope, intercept float64) {
+	n := float64(len(xs))
+	var sumX, sumY, sumXY, sumXX float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+		sumXY += xs[i] * ys[i]
+		sumXX += xs[i] * xs[i]
+	}
+
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0, sumY / n
+	}
+	slope = (n*sumXY - sumX*sumY) / denominator
+	intercept = (sumY - slope*sumX) / n
+	return slope, intercept
+}
+
+// Доля от максимальной скорости улучшения, ниже которой окно считается
+// фазой эксплуатации.
+const phaseThresholdRatio = 0.1
+
+// Скорости улучшения и дисперсии лучшей приспособленности по скользящим
+// окнам; элемент i описывает поколения [i, i+window). Boundary — первое
+// поколение после окна с максимальной скоростью, с которого скорость падает
+// ниже Threshold (исследование → эксплуатация), или -1, если такого нет.
+// Скорость улучшения положительна при приближении к оптимуму в обоих
+// направлениях: при минимизации это скорость убывания.
+type PhaseReport struct {
+	Window           int
+	ImprovementRates []float64
+	Variances        []float64
+	Threshold        float64
+	Boundary         int
+}
+
+func AnalyzeConvergencePhases(convergence []float64, window int, minimize bool) PhaseReport {
+	report := PhaseReport{Window: window, Boundary: -1}
+	if window < 2 || len(convergence) < window {
+		return report
+	}
+	convergence = improvementCurve(convergence, minimize)
+
+	count := len(convergence) - window + 1
+	report.ImprovementRates = make([]float64, count)
+	report.Variances = make([]float64, count)
+
+	peak := 0
+	for i := 0; i < count; i++ {
+		segment := convergence[i : i+window]
+		report.ImprovementRates[i] = (segment[window-1] - segment[0]) / float64(window-1)
+
+		mean := 0.0
+		for _, v := range segment {
+			mean += v
+		}
+		mean /= float64(window)
+		report.Variances[i] = math.Pow(StdDev(segment, mean), 2)
+
+		if report.ImprovementRates[i] > report.ImprovementRates[peak] {
+			peak = i
+		}
+	}
+
+	report.Threshold = report.ImprovementRates[peak] * phaseThresholdRatio
+	if report.ImprovementRates[peak] <= 0 {
+		return report
+	}
+
+	for i := peak + 1; i < count; i++ {
+		if report.ImprovementRates[i] < report.Threshold {
+			report.Boundary = i
+			break
+		}
+	}
+	return report
+}
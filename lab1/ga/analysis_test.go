@@ -0,0 +1,69 @@
+package ga
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// Кривая f(t) = c ± 8·e^(-0.3·t): рост к 10 при максимизации и убывание
+// к 2 при минимизации.
+func exponentialCurve(minimize bool) []float64 {
+	curve := make([]float64, 60)
+	for t := range curve {
+		if minimize {
+			curve[t] = 2 + 8*math.Exp(-0.3*float64(t))
+		} else {
+			curve[t] = 10 - 8*math.Exp(-0.3*float64(t))
+		}
+	}
+	return curve
+}
+
+func TestFitConvergenceRateBothDirections(t *testing.T) {
+	// Предел берётся по последней точке, поэтому оценка чуть смещена.
+	maxRate, maxR2 := FitConvergenceRate(exponentialCurve(false), false)
+	if math.Abs(maxRate-0.3) > 0.02 || maxR2 < 0.99 {
+		t.Fatalf("максимизация: rate = %v, R² = %v, ожидалось ≈0.3 и ≈1", maxRate, maxR2)
+	}
+	minRate, minR2 := FitConvergenceRate(exponentialCurve(true), true)
+	if math.Abs(minRate-maxRate) > 1e-6 || math.Abs(minR2-maxR2) > 1e-6 {
+		t.Fatalf("минимизация: rate = %v, R² = %v, при максимизации %v, %v", minRate, minR2, maxRate, maxR2)
+	}
+
+	// Без учёта направления кривая минимизации выглядит как застой.
+	if rate, _ := FitConvergenceRate(exponentialCurve(true), false); rate != 0 {
+		t.Fatalf("кривая минимизации как максимизация: rate = %v, ожидалось 0", rate)
+	}
+}
+
+func TestAnalyzeConvergencePhasesBothDirections(t *testing.T) {
+	maximize := AnalyzeConvergencePhases(exponentialCurve(false), 5, false)
+	minimize := AnalyzeConvergencePhases(exponentialCurve(true), 5, true)
+
+	if maximize.Boundary <= 0 {
+		t.Fatalf("граница фаз не найдена: %+v", maximize)
+	}
+	if minimize.Boundary != maximize.Boundary {
+		t.Fatalf("граница при минимизации %d, при максимизации %d", minimize.Boundary, maximize.Boundary)
+	}
+	if minimize.ImprovementRates[0] <= 0 || minimize.Threshold <= 0 {
+		t.Fatalf("скорость улучшения при минимизации не положительна: %+v", minimize)
+	}
+	for i := range maximize.ImprovementRates {
+		if math.Abs(minimize.ImprovementRates[i]-maximize.ImprovementRates[i]) > 1e-9 ||
+			math.Abs(minimize.Variances[i]-maximize.Variances[i]) > 1e-9 {
+			t.Fatalf("окно %d: минимизация %v/%v, максимизация %v/%v", i,
+				minimize.ImprovementRates[i], minimize.Variances[i],
+				maximize.ImprovementRates[i], maximize.Variances[i])
+		}
+	}
+
+	curve := exponentialCurve(true)
+	original := slices.Clone(curve)
+	AnalyzeConvergencePhases(curve, 5, true)
+	FitConvergenceRate(curve, true)
+	if !slices.Equal(curve, original) {
+		t.Fatal("анализ изменил исходную кривую")
+	}
+}
@@ -0,0 +1,46 @@
+package ga
+
+type cachedFitness struct {
+	fitness  float64
+	evalCost float64
+}
+
+// Кэш приспособленности по генотипу для одного запуска. При
+// FitnessSamples > 1 функция считается зашумлённой и кэш не используется.
+type fitnessCache struct {
+	entries map[string]cachedFitness
+	hits    int
+}
+
+func (ga *GeneticAlgorithm) resetCache() {
+	ga.cache = fitnessCache{}
+	if ga.config.EnableFitnessCache && ga.config.FitnessSamples <= 1 {
+		ga.cache.entries = make(map[string]cachedFitness)
+	}
+}
+
+func (ga *GeneticAlgorithm) cachedEvaluate(individual *Individual) bool {
+	if ga.cache.entries == nil {
+		return false
+	}
+	entry, ok := ga.cache.entries[genotypeKey(*individual)]
+	if !ok {
+		return false
+	}
+	individual.Fitness = entry.fitness
+	individual.EvalCost = entry.evalCost
+	ga.cache.hits++
+	return true
+}
+
+func (ga *GeneticAlgorithm) storeFitness(individual Individual) {
+	if ga.cache.entries != nil {
+		ga.cache.entries[genotypeKey(individual)] = cachedFitness{individual.Fitness, individual.EvalCost}
+	}
+}
+
+// Число вычислений приспособленности, заменённых значением из кэша,
+// за последний Run.
+func (ga *GeneticAlgorithm) CacheHits() int {
+	return ga.cache.hits
+}
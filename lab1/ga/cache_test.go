@@ -0,0 +1,56 @@
+package ga
+
+import "testing"
+
+// Повторная оценка того же генотипа берётся из кэша: функция вызывается
+// один раз на генотип, остальные оценки учитываются в CacheHits.
+func TestFitnessCacheCountsDuplicateGenomes(t *testing.T) {
+	calls := 0
+	config := validConfig()
+	config.EnableFitnessCache = true
+	config.FitnessFunc = func(genes []byte) float64 {
+		calls++
+		return float64(BytesToInt(genes))
+	}
+
+	algorithm := NewGeneticAlgorithm(config)
+	algorithm.resetCache()
+	first := Individual{Genes: []byte{1, 0, 1, 0, 0, 0, 0, 0}}
+	duplicate := Individual{Genes: []byte{1, 0, 1, 0, 0, 0, 0, 0}}
+	algorithm.evaluate(&first)
+	algorithm.evaluate(&duplicate)
+	if calls != 1 || algorithm.CacheHits() != 1 || duplicate.Fitness != first.Fitness {
+		t.Fatalf("вызовов %d, попаданий %d, приспособленность %v и %v",
+			calls, algorithm.CacheHits(), first.Fitness, duplicate.Fitness)
+	}
+
+	// Всего 8 генотипов: за запуск функция вызывается не больше 8 раз,
+	// и каждая оценка — либо вызов, либо попадание в кэш.
+	config.BitsPerGene = 3
+	config.PopulationSize = 20
+	config.MaxGenerations = 10
+	calls = 0
+	algorithm = NewGeneticAlgorithm(config)
+	algorithm.Run()
+	if calls > 8 {
+		t.Fatalf("функция вызвана %d раз на 8 генотипах", calls)
+	}
+	if algorithm.Stats().FitnessEvaluations != calls || algorithm.CacheHits() == 0 {
+		t.Fatalf("вычислений %d при %d вызовах, попаданий %d",
+			algorithm.Stats().FitnessEvaluations, calls, algorithm.CacheHits())
+	}
+
+	config.EnableFitnessCache = false
+	algorithm = NewGeneticAlgorithm(config)
+	if algorithm.Run(); algorithm.CacheHits() != 0 {
+		t.Fatalf("без кэша %d попаданий", algorithm.CacheHits())
+	}
+
+	// Зашумлённая функция (FitnessSamples > 1) не кэшируется.
+	config.EnableFitnessCache = true
+	config.FitnessSamples = 2
+	algorithm = NewGeneticAlgorithm(config)
+	if algorithm.Run(); algorithm.CacheHits() != 0 {
+		t.Fatalf("при FitnessSamples = 2 %d попаданий", algorithm.CacheHits())
+	}
+}
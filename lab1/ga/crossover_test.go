@@ -0,0 +1,152 @@
+package ga
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+// Каждый ген потомка должен происходить от одного из родителей в той же
+// позиции: child[i] ∈ {parent1[i], parent2[i]}.
+func TestCrossoverPreservesAlleles(t *testing.T) {
+	algorithm := NewGeneticAlgorithm(Config{
+		PopulationSize: 2,
+		BitsPerGene:    8,
+		FitnessFunc:    func([]byte) float64 { return 0 },
+		Seed:           11,
+	})
+	rng := NewRand("", 3)
+	randomParent := func(length int) Individual {
+		genes := make([]byte, length)
+		for i := range genes {
+			genes[i] = byte(rng.Intn(2))
+		}
+		return Individual{Genes: genes}
+	}
+
+	for _, crossoverType := range []string{"uniform", "onepoint", "twopoint"} {
+		for trial := 0; trial < 500; trial++ {
+			length := 1 + rng.Intn(40)
+			parent1, parent2 := randomParent(length), randomParent(length)
+
+			children := algorithm.crossover(crossoverType, parent1, parent2, 2)
+			if len(children) != 2 {
+				t.Fatalf("%s: получено %d потомков, ожидалось 2", crossoverType, len(children))
+			}
+			for c, child := range children {
+				if len(child.Genes) != length {
+					t.Fatalf("%s: длина потомка %d равна %d, ожидалась %d", crossoverType, c, len(child.Genes), length)
+				}
+				for i, gene := range child.Genes {
+					if gene != parent1.Genes[i] && gene != parent2.Genes[i] {
+						t.Fatalf("%s: ген %d потомка %d равен %d, у родителей %d и %d",
+							crossoverType, i, c, gene, parent1.Genes[i], parent2.Genes[i])
+					}
+				}
+			}
+			// Аллели не теряются: в каждой позиции пара потомков несёт те же
+			// значения, что пара родителей.
+			for i := range parent1.Genes {
+				if children[0].Genes[i]+children[1].Genes[i] != parent1.Genes[i]+parent2.Genes[i] {
+					t.Fatalf("%s: в позиции %d потомки %d и %d, родители %d и %d", crossoverType, i,
+						children[0].Genes[i], children[1].Genes[i], parent1.Genes[i], parent2.Genes[i])
+				}
+			}
+		}
+	}
+}
+
+// Двухточечное скрещивание с фиксированным зерном: точки разреза
+// упорядочиваются, средний участок [a, b) меняется между родителями,
+// при совпадающих точках потомки — копии родителей.
+func TestTwoPointCrossoverKnownCuts(t *testing.T) {
+	parent1 := Individual{Genes: []byte{10, 11, 12, 13, 14, 15, 16, 17}}
+	parent2 := Individual{Genes: []byte{20, 21, 22, 23, 24, 25, 26, 27}}
+	tests := []struct {
+		seed           int64
+		child1, child2 []byte
+	}{
+		// Точки 0 и 3.
+		{2, []byte{20, 21, 22, 13, 14, 15, 16, 17}, []byte{10, 11, 12, 23, 24, 25, 26, 27}},
+		// Точки 7 и 5 упорядочиваются в [5, 7).
+		{4, []byte{10, 11, 12, 13, 14, 25, 26, 17}, []byte{20, 21, 22, 23, 24, 15, 16, 27}},
+		// Точки 3 и 3: обмена нет.
+		{1, parent1.Genes, parent2.Genes},
+	}
+	for _, tt := range tests {
+		algorithm := NewGeneticAlgorithm(Config{
+			PopulationSize: 2,
+			BitsPerGene:    8,
+			FitnessFunc:    func([]byte) float64 { return 0 },
+			Seed:           tt.seed,
+		})
+		child1, child2 := algorithm.twopointCrossover(parent1, parent2)
+		if !bytes.Equal(child1.Genes, tt.child1) || !bytes.Equal(child2.Genes, tt.child2) {
+			t.Fatalf("зерно %d: потомки %v и %v, ожидались %v и %v",
+				tt.seed, child1.Genes, child2.Genes, tt.child1, tt.child2)
+		}
+	}
+
+	if parent1.Genes[0] != 10 || parent2.Genes[0] != 20 {
+		t.Fatal("скрещивание изменило гены родителей")
+	}
+
+	// Хромосома из одного гена: потомки — копии родителей.
+	algorithm := NewGeneticAlgorithm(Config{PopulationSize: 2, BitsPerGene: 1, FitnessFunc: func([]byte) float64 { return 0 }})
+	child1, child2 := algorithm.twopointCrossover(Individual{Genes: []byte{0}}, Individual{Genes: []byte{1}})
+	if !bytes.Equal(child1.Genes, []byte{0}) || !bytes.Equal(child2.Genes, []byte{1}) {
+		t.Fatalf("один ген: потомки %v и %v", child1.Genes, child2.Genes)
+	}
+}
+
+// При UniformMixRatio = 0.9 первый потомок наследует от первого родителя
+// около 90% генов в каждой позиции, второй — столько же от второго; по
+// умолчанию (0) доля — 50%.
+func TestUniformMixRatioInheritanceBias(t *testing.T) {
+	const (
+		loci   = 48
+		trials = 2000
+	)
+	parent1 := Individual{Genes: make([]byte, loci)}
+	parent2 := Individual{Genes: allOnes(loci)}
+
+	for _, ratio := range []float64{0.9, 0} {
+		config := validConfig()
+		config.BitsPerGene = loci
+		config.UniformMixRatio = ratio
+		config.Seed = 17
+		if err := config.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		algorithm := NewGeneticAlgorithm(config)
+
+		fromFirst := make([]int, loci)
+		for trial := 0; trial < trials; trial++ {
+			child1, child2 := algorithm.uniformCrossover(parent1, parent2)
+			for i := range child1.Genes {
+				if child1.Genes[i] == child2.Genes[i] {
+					t.Fatalf("позиция %d: потомки получили один и тот же ген", i)
+				}
+				if child1.Genes[i] == 0 {
+					fromFirst[i]++
+				}
+			}
+		}
+
+		want := ratio
+		if want == 0 {
+			want = 0.5
+		}
+		total := 0
+		for i, count := range fromFirst {
+			// Больше пяти стандартных отклонений биномиального распределения.
+			if got := float64(count) / trials; math.Abs(got-want) > 5*math.Sqrt(want*(1-want)/trials) {
+				t.Fatalf("ratio %v, позиция %d: от первого родителя %.3f генов, ожидалось около %v", ratio, i, got, want)
+			}
+			total += count
+		}
+		if got := float64(total) / (loci * trials); math.Abs(got-want) > 0.005 {
+			t.Fatalf("ratio %v: в среднем от первого родителя %.4f генов, ожидалось %v", ratio, got, want)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package ga
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Знаковое целое в дополнительном коде: старший (последний) ген — знак.
+// Порядок битов тот же, что у BytesToInt. Учитываются не более 64 генов.
+func BytesToSignedInt(genes []byte) int64 {
+	n := len(genes)
+	if n > 64 {
+		n = 64
+	}
+	if n == 0 {
+		return 0
+	}
+
+	var value uint64
+	for i := 0; i < n; i++ {
+		if genes[i] == 1 {
+			value |= 1 << i
+		}
+	}
+
+	// Расширение знака на старшие разряды.
+	if n < 64 && genes[n-1] == 1 {
+		value |= ^uint64(0) << n
+	}
+	return int64(value)
+}
+
+// Обратное к BytesToSignedInt: младшие bits разрядов дополнительного кода.
+// Значения вне [-2^(bits-1), 2^(bits-1)-1] усекаются по модулю 2^bits.
+func SignedIntToBytes(value int64, bits int) []byte {
+	genes := make([]byte, bits)
+	for i := 0; i < bits; i++ {
+		shift := i
+		if shift > 63 {
+			shift = 63
+		}
+		genes[i] = byte((uint64(value) >> shift) & 1)
+	}
+	return genes
+}
+
+// Число с фиксированной точкой: знаковое целое, делённое на 2^fracBits.
+func BytesToFixedPoint(genes []byte, fracBits int) float64 {
+	return math.Ldexp(float64(BytesToSignedInt(genes)), -fracBits)
+}
+
+// Обратное к BytesToFixedPoint: ближайшее представимое значение, значения
+// вне диапазона насыщаются до наибольшего по модулю.
+func FixedPointToBytes(value float64, bits, fracBits int) []byte {
+	if bits <= 0 {
+		return []byte{}
+	}
+
+	scaled := math.Round(math.Ldexp(value, fracBits))
+	limit := math.Ldexp(1, bits-1)
+	switch {
+	case math.IsNaN(scaled):
+		scaled = 0
+	case scaled < -limit:
+		scaled = -limit
+	case scaled > limit-1:
+		scaled = limit - 1
+	}
+
+	var integer int64
+	switch {
+	case scaled >= math.MaxInt64:
+		integer = math.MaxInt64
+	case scaled <= math.MinInt64:
+		integer = math.MinInt64
+	default:
+		integer = int64(scaled)
+	}
+	return SignedIntToBytes(integer, bits)
+}
+
+// Код Грея: соседние целые отличаются ровно одним битом, поэтому малое
+// изменение решения не требует одновременной мутации многих битов
+// («обрыв Хэмминга» у обычного двоичного кода). Порядок битов тот же,
+// что у BytesToInt.
+func GrayToInt(genes []byte) int {
+	return int(grayToUint64(genes))
+}
+
+func grayToUint64(genes []byte) uint64 {
+	gray := bytesToUint64(genes)
+	value := gray
+	for shift := gray >> 1; shift != 0; shift >>= 1 {
+		value ^= shift
+	}
+	return value
+}
+
+// Обратное к GrayToInt: младшие bits разрядов кода Грея числа value.
+func IntToGray(value, bits int) []byte {
+	gray := uint64(value) ^ (uint64(value) >> 1)
+	genes := make([]byte, bits)
+	for i := 0; i < bits && i < 64; i++ {
+		if gray&(1<<i) != 0 {
+			genes[i] = 1
+		}
+	}
+	return genes
+}
+
+// Как BytesToFloat, но хромосома читается как код Грея.
+func BytesToFloatGray(genes []byte, min, max float64) float64 {
+	if len(genes) == 0 {
+		return min
+	}
+	return min + float64(grayToUint64(genes))/float64(maxDecodedValue(len(genes)))*(max-min)
+}
+
+// Декодирование вещественного числа в кодировке Config.Encoding:
+// "gray" или двоичной (по умолчанию).
+func DecodeFloat(genes []byte, min, max float64, encoding string) float64 {
+	if encoding == "gray" {
+		return BytesToFloatGray(genes, min, max)
+	}
+	return BytesToFloat(genes, min, max)
+}
+
+// Обратное к DecodeFloat.
+func EncodeFloat(value, min, max float64, bits int, encoding string) []byte {
+	genes := FloatToBytes(value, min, max, bits)
+	if encoding == "gray" {
+		return IntToGray(int(bytesToUint64(genes)), bits)
+	}
+	return genes
+}
+
+// Декодирование целого в кодировке Config.Encoding.
+func DecodeInt(genes []byte, encoding string) int {
+	if encoding == "gray" {
+		return GrayToInt(genes)
+	}
+	return BytesToInt(genes)
+}
+
+// Индекс в массиве из n элементов: декодированное значение v из 2^len
+// возможных масштабируется как ⌊v·n/2^len⌋. Каждому индексу соответствует
+// ⌊2^len/n⌋ или ⌈2^len/n⌉ генотипов, поэтому при 2^len >= n отображение
+// сюръективно и почти равномерно, в отличие от взятия по модулю, при котором
+// первые 2^len mod n индексов достижимы вдвое большим числом генотипов.
+func DecodeIndex(genes []byte, n int, encoding string) int {
+	if n <= 0 || len(genes) == 0 {
+		return 0
+	}
+	value := uint64(DecodeInt(genes, encoding))
+	hi, lo := bits.Mul64(value, uint64(n))
+	length := uint(len(genes))
+	return int(hi<<(64-length) | lo>>length)
+}
@@ -0,0 +1,137 @@
+package ga
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// Граница знака дополнительного кода: минимум, −1, 0 и максимум при
+// разной длине, включая 1 и 64 бита.
+func TestSignedIntSignBoundary(t *testing.T) {
+	for _, bits := range []int{1, 2, 8, 16, 63, 64} {
+		minValue := -int64(1) << (bits - 1)
+		maxValue := int64(uint64(1)<<(bits-1) - 1)
+		for _, value := range []int64{minValue, -1, 0, maxValue} {
+			genes := SignedIntToBytes(value, bits)
+			if got := BytesToSignedInt(genes); got != value {
+				t.Fatalf("%d бит: %d → %v → %d", bits, value, genes, got)
+			}
+		}
+	}
+
+	tests := []struct {
+		value int64
+		genes []byte // младший бит первым
+	}{
+		{-128, []byte{0, 0, 0, 0, 0, 0, 0, 1}},
+		{-1, []byte{1, 1, 1, 1, 1, 1, 1, 1}},
+		{0, []byte{0, 0, 0, 0, 0, 0, 0, 0}},
+		{127, []byte{1, 1, 1, 1, 1, 1, 1, 0}},
+	}
+	for _, tt := range tests {
+		if got := SignedIntToBytes(tt.value, 8); !slices.Equal(got, tt.genes) {
+			t.Fatalf("SignedIntToBytes(%d, 8) = %v, ожидалось %v", tt.value, got, tt.genes)
+		}
+	}
+
+	// За границей диапазона значение усекается по модулю 2^bits.
+	if got := BytesToSignedInt(SignedIntToBytes(128, 8)); got != -128 {
+		t.Fatalf("128 в 8 битах: %d, ожидалось -128", got)
+	}
+}
+
+// Фиксированная точка: граничные значения и насыщение вне диапазона.
+func TestFixedPointBoundary(t *testing.T) {
+	const bits, fracBits = 8, 4
+	tests := []struct {
+		value, want float64
+	}{
+		{-8, -8},
+		{-1.0 / 16, -1.0 / 16},
+		{0, 0},
+		{8 - 1.0/16, 8 - 1.0/16},
+		{100, 8 - 1.0/16},
+		{-100, -8},
+		{math.NaN(), 0},
+	}
+	for _, tt := range tests {
+		if got := BytesToFixedPoint(FixedPointToBytes(tt.value, bits, fracBits), fracBits); got != tt.want {
+			t.Fatalf("%v → %v, ожидалось %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+// Код Грея: IntToGray и GrayToInt взаимно обратны, соседние целые
+// отличаются ровно одним битом.
+func TestGrayRoundTrip(t *testing.T) {
+	for bits := 1; bits <= 12; bits++ {
+		previous := IntToGray(0, bits)
+		for value := 0; value < 1<<bits; value++ {
+			genes := IntToGray(value, bits)
+			if got := GrayToInt(genes); got != value {
+				t.Fatalf("%d бит: %d → %v → %d", bits, value, genes, got)
+			}
+			if value > 0 {
+				if d, _ := HammingDistance(previous, genes); d != 1 {
+					t.Fatalf("%d бит: коды %d и %d различаются в %d битах", bits, value-1, value, d)
+				}
+			}
+			previous = genes
+		}
+	}
+
+	// 2 = 10₂ → код Грея 11₂, младший бит первым.
+	if genes := IntToGray(2, 3); !slices.Equal(genes, []byte{1, 1, 0}) {
+		t.Fatalf("IntToGray(2, 3) = %v, ожидалось [1 1 0]", genes)
+	}
+	const large = 1<<62 + 12345
+	if got := GrayToInt(IntToGray(large, 63)); got != large {
+		t.Fatalf("63 бита: %d → %d", large, got)
+	}
+
+	// Вещественное значение восстанавливается с точностью до полушага сетки.
+	for _, x := range []float64{-2, -0.5, 0, 1.25, 2} {
+		genes := EncodeFloat(x, -2, 2, 4, "gray")
+		if got := DecodeFloat(genes, -2, 2, "gray"); math.Abs(got-x) > 4.0/15/2 {
+			t.Fatalf("%v → %v → %v", x, genes, got)
+		}
+	}
+}
+
+// DecodeIndex переводит все 2^bits генотипов на все n индексов, и на
+// каждый индекс приходится ⌊2^bits/n⌋ или ⌈2^bits/n⌉ генотипов — в обеих
+// кодировках, включая размер массива из задачи 1 (10^6 при 20 битах).
+func TestDecodeIndexIsUniformSurjection(t *testing.T) {
+	cases := []struct{ bits, n int }{
+		{10, 1}, {10, 3}, {10, 7}, {10, 600}, {10, 1000}, {10, 1024}, {20, 1000000},
+	}
+	for _, tc := range cases {
+		for _, encoding := range []string{"binary", "gray"} {
+			counts := make([]int, tc.n)
+			for value := 0; value < 1<<tc.bits; value++ {
+				genes := SignedIntToBytes(int64(value), tc.bits)
+				if encoding == "gray" {
+					genes = IntToGray(value, tc.bits)
+				}
+				index := DecodeIndex(genes, tc.n, encoding)
+				if index < 0 || index >= tc.n {
+					t.Fatalf("%d бит, n = %d, %s: генотип %d → индекс %d вне массива", tc.bits, tc.n, encoding, value, index)
+				}
+				counts[index]++
+			}
+
+			floor := (1 << tc.bits) / tc.n
+			for index, count := range counts {
+				if count != floor && count != floor+1 {
+					t.Fatalf("%d бит, n = %d, %s: на индекс %d приходится %d генотипов, ожидалось %d или %d",
+						tc.bits, tc.n, encoding, index, count, floor, floor+1)
+				}
+			}
+		}
+	}
+
+	if got := DecodeIndex(nil, 10, "binary"); got != 0 {
+		t.Errorf("пустой генотип → %d, ожидалось 0", got)
+	}
+}
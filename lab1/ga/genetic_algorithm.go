@@ -1,14 +1,25 @@
 package ga
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
 	"math"
-	"math/rand"
+	"slices"
 	"sort"
+	"time"
 )
 
 type Individual struct {
-	Genes   []byte
-	Fitness float64
+	Genes     []byte
+	RealGenes []float64
+	Fitness   float64
+	EvalCost  float64
+	ID        int
+	// Значения целевых функций в многокритериальном режиме
+	// (см. RunMultiObjective); в остальных режимах nil.
+	Objectives []float64
 }
 
 type Config struct {
@@ -21,98 +32,647 @@ type Config struct {
 	BitsPerGene    int
 	FitnessFunc    func([]byte) float64
 	Seed           int64
+	ReportWriter   io.Writer
+	ReportInterval int
+	// Если больше 0, при турнирном отборе из особей с равной
+	// приспособленностью побеждает та, чья приспособленность вычислялась
+	// быстрее (EvalCost, мс). Разную приспособленность время не перевешивает,
+	// но исход таких турниров зависит от замеров, и запуски с одним Seed
+	// могут разойтись.
+	TimePenaltyFactor float64
+	// Запись происхождения особей. После каждого поколения хранятся только
+	// предки текущей популяции, так что память растёт с глубиной
+	// родословной, а не с PopulationSize·MaxGenerations.
+	TrackLineage bool
+	// "bitflip" (по умолчанию), "boundarylocal": младшие биты
+	// мутируют чаще старших, сила перекоса — BitSignificanceBias в [0, 1],
+	// или "swap": с вероятностью MutationProb на особь два случайных гена
+	// меняются местами. Swap сохраняет набор значений генов, поэтому
+	// подходит для перестановочных задач; MutationRates и мутация длины
+	// хромосомы при нём не применяются.
+	MutationType        string
+	BitSignificanceBias float64
+	// Вероятность мутации для каждого локуса; если задан, заменяет
+	// MutationProb и MutationType. Длина — BitsPerGene (при VariableLength —
+	// максимальная длина хромосомы).
+	MutationRates []float64
+	// Число вызовов FitnessFunc, усредняемых для одной особи (для
+	// зашумлённой приспособленности). Каждый вызов считается отдельным
+	// вычислением; кэшировать такие значения нельзя.
+	FitnessSamples int
+	// Хромосомы переменной длины в пределах [MinGenes, MaxGenes]
+	// (по умолчанию [1, BitsPerGene]). InsertProb и DeleteProb — вероятности
+	// вставки и удаления одного случайного бита у потомка.
+	VariableLength bool
+	MinGenes       int
+	MaxGenes       int
+	InsertProb     float64
+	DeleteProb     float64
+	// Смесь операторов скрещивания: для каждого скрещивания тип выбирается
+	// случайно с указанными весами (вместо CrossoverType). При
+	// AdaptiveOperators веса подстраиваются под долю удачных потомков.
+	CrossoverMix      map[string]float64
+	AdaptiveOperators bool
+	// Генератор случайных чисел: Rand, если задан, иначе NewRand(RandSource, Seed).
+	RandSource string
+	Rand       Rand
+	// Двухфазный режим: доля PhaseSplit поколений (по умолчанию 0.5) —
+	// двоичный ГА, остальные — вещественное уточнение вокруг лучшего
+	// решения в [DecodeMin, DecodeMax] с приспособленностью RealFitnessFunc.
+	TwoPhase        bool
+	PhaseSplit      float64
+	DecodeMin       float64
+	DecodeMax       float64
+	RealFitnessFunc func(float64) float64
+	// Элитные места занимают только различные генотипы (см. elites).
+	UniqueElites bool
+	// Сколько последних поколений хранить для отладки (0 — не хранить).
+	SnapshotGenerations int
+	// Журнал улучшений глобального лучшего (см. Improvements). Запись в
+	// ImprovementWriter включает журнал без LogImprovements.
+	LogImprovements   bool
+	ImprovementWriter io.Writer
+	// Сколько потомков порождает одна пара родителей (по умолчанию — столько,
+	// сколько вставляется, то есть не больше двух). Если больше, в популяцию
+	// попадают лучшие из них.
+	OffspringPerMating int
+	// Минимизировать FitnessFunc вместо максимизации (по умолчанию false).
+	// «Лучшая» особь тогда — с наименьшим значением во всех сравнениях.
+	Minimize bool
+	// Размер турнира (по умолчанию 3, не больше размера популяции).
+	// Больший турнир усиливает давление отбора.
+	TournamentSize int
+	// Способ отбора родителей: "tournament" (по умолчанию), "roulette"
+	// или "rank" (линейный ранговый с давлением RankPressure в [1, 2]).
+	SelectionType string
+	RankPressure  float64
+	// Ранняя остановка: если лучшая приспособленность StagnationLimit
+	// поколений подряд улучшается не больше чем на ImprovementEpsilon,
+	// Run завершается (0 — без ранней остановки) или, при RestartFraction > 0,
+	// перезапускает часть популяции.
+	StagnationLimit    int
+	ImprovementEpsilon float64
+	// Кэшировать приспособленность по генотипу в пределах одного Run
+	// (игнорируется при FitnessSamples > 1). См. CacheHits.
+	EnableFitnessCache bool
+	// Кодировка хромосомы: "binary" (по умолчанию), "gray" или "real".
+	// С битами ГА работает одинаково; кодировку Грея учитывают FitnessFunc
+	// (через DecodeFloat/DecodeInt) и двухфазный режим. "real" — вещественные
+	// гены RealGenes вместо битов (см. RealBounds).
+	Encoding string
+	// Вызывается после сортировки каждого поколения (nil — не вызывается).
+	OnGeneration func(gen int, stats GenerationStats)
+	// Для Encoding: "real" — границы каждого измерения (по умолчанию одно
+	// измерение [DecodeMin, DecodeMax]), функция приспособленности вектора
+	// (по умолчанию RealFitnessFunc от первой координаты) и относительный
+	// шаг гауссовой мутации (по умолчанию 0.1).
+	RealBounds            [][2]float64
+	RealVectorFitnessFunc func([]float64) float64
+	MutationSigma         float64
+	// Затухающая мутация: вероятность мутации каждого бита (и MutationRates)
+	// с каждым поколением экспоненциально приближается к MutationFloor,
+	// теряя долю MutationDecay (по умолчанию 0.05) превышения над ним.
+	AdaptiveMutation bool
+	MutationDecay    float64
+	MutationFloor    float64
+	// Размер элиты как доля популяции в [0, 1); если не 0, заменяет
+	// ElitismCount (см. EliteCount).
+	ElitismFraction float64
+	// Перезапуск вместо ранней остановки: если задан StagnationLimit и
+	// RestartFraction > 0, при застое эта доля худших особей (без элиты)
+	// заменяется случайными, и запуск продолжается (см. Restarts).
+	RestartFraction float64
+	// Целевые функции для RunMultiObjective: все максимизируются (при
+	// Minimize — минимизируются). Run их не использует.
+	FitnessFuncMulti func([]byte) []float64
+	// Реакция на NaN и бесконечную приспособленность: "error" (по
+	// умолчанию) — Run останавливается, и ошибку возвращают Err и
+	// RunWithContext; "penalize" — особь получает худшую возможную
+	// приспособленность и запуск продолжается. В обоих случаях число таких
+	// оценок — RunStats.InvalidFitness.
+	NaNPolicy string
+	// Вероятность, с которой при равномерном скрещивании первый потомок
+	// наследует ген первого родителя (второй — второго), в (0, 1); по
+	// умолчанию 0.5. При 0.9 потомки в среднем на 90% копируют «своего»
+	// родителя.
+	UniformMixRatio float64
+	// Ограничение времени одного Run (0 — без ограничения). Проверяется
+	// после учёта каждого поколения, включая вещественную фазу TwoPhase;
+	// при превышении Run возвращает лучшую особь текущего поколения,
+	// TerminationReason — TerminationTimeBudget, Err остаётся пустой.
+	MaxDuration time.Duration
+}
+
+// Дифференциал отбора S (средняя приспособленность отобранных родителей
+// минус средняя по популяции) и ответ на отбор R (изменение средней
+// приспособленности в следующем поколении) — по одному значению на поколение.
+// DistinctIndividuals — число различных генотипов в каждом поколении,
+// FinalDistinct — в итоговой популяции. FitnessEvaluations — число
+// вызовов функции приспособленности за запуск. Entropy — средняя
+// по локусам энтропия популяции (см. PopulationEntropy) в каждом поколении.
+// MeanFitness и StdDevFitness — средняя приспособленность популяции и её
+// разброс в каждом поколении. MutationProb — действующая (с учётом
+// AdaptiveMutation) вероятность мутации в каждом поколении, Diversity —
+// разнообразие популяции (см. PopulationDiversity). InvalidFitness — число
+// оценок с NaN или бесконечной приспособленностью (см. NaNPolicy).
+type RunStats struct {
+	SelectionDifferential []float64
+	SelectionResponse     []float64
+	DistinctIndividuals   []int
+	FinalDistinct         int
+	FitnessEvaluations    int
+	Entropy               []float64
+	MeanFitness           []float64
+	StdDevFitness         []float64
+	MutationProb          []float64
+	Diversity             []float64
+	InvalidFitness        int
+}
+
+// История сходимости одного запуска: лучшая, средняя приспособленность
+// и стандартное отклонение по поколениям (срезы одной длины).
+type ConvergenceHistory struct {
+	Best   []float64
+	Mean   []float64
+	StdDev []float64
 }
 
 type GeneticAlgorithm struct {
-	config      Config
-	population  []Individual
-	bestFitness []float64
-	rng         *rand.Rand
+	config       Config
+	population   []Individual
+	bestFitness  []float64
+	rng          Rand
+	lineage      map[int]LineageRecord
+	nextID       int
+	stats        RunStats
+	termination  string
+	snapshots    snapshotRing
+	operators    operatorMix
+	improvements improvementLog
+	observer     func(Generation) bool
+	err          error
+	cache        fitnessCache
+	generation   int
+	restarts     []int
+	runStart     time.Time
+}
+
+// Минимальный размер популяции, при котором кроме элиты остаётся место
+// хотя бы для одной пары потомков. При меньшем размере ГА почти не
+// размножается и сходимость вырождается в плоскую линию.
+func MinViablePopulation(config Config) int {
+	return EliteCount(config) + 2
+}
+
+// Действующий размер элиты: round(ElitismFraction·PopulationSize), но не
+// больше PopulationSize-1, если доля задана, иначе ElitismCount.
+func EliteCount(config Config) int {
+	if config.ElitismFraction == 0 {
+		return config.ElitismCount
+	}
+	count := int(math.Round(config.ElitismFraction * float64(config.PopulationSize)))
+	return max(0, min(count, config.PopulationSize-1))
 }
 
+// Config не проверяется: конструктор принимает любые параметры, как и
+// прежде. Для проверки (см. Config.Validate) — NewGeneticAlgorithmChecked.
 func NewGeneticAlgorithm(config Config) *GeneticAlgorithm {
+	if config.Rand == nil {
+		config.Rand = NewRand(config.RandSource, config.Seed)
+	}
 	return &GeneticAlgorithm{
 		config:      config,
 		bestFitness: make([]float64, 0),
-		rng:         rand.New(rand.NewSource(config.Seed)),
+		rng:         config.Rand,
+	}
+}
+
+// Как NewGeneticAlgorithm, но некорректный Config возвращается ошибкой.
+func NewGeneticAlgorithmChecked(config Config) (*GeneticAlgorithm, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
 	}
+	return NewGeneticAlgorithm(config), nil
 }
 
 func (ga *GeneticAlgorithm) Initialize() {
+	ga.resetLineage()
+	ga.stats = RunStats{}
+	ga.generation = 0
+	ga.restarts = nil
+	ga.bestFitness = make([]float64, 0)
+	ga.snapshots = newSnapshotRing(ga.config.SnapshotGenerations)
+	ga.resetImprovements()
+	ga.resetCache()
+	ga.operators = newOperatorMix(ga.config.CrossoverMix)
 	ga.population = make([]Individual, ga.config.PopulationSize)
 	for i := 0; i < ga.config.PopulationSize; i++ {
-		genes := make([]byte, ga.config.BitsPerGene)
-		for j := 0; j < ga.config.BitsPerGene; j++ {
-			if ga.rng.Float64() < 0.5 {
-				genes[j] = 1
-			} else {
-				genes[j] = 0
-			}
-		}
-		ga.population[i] = Individual{
-			Genes:   genes,
-			Fitness: ga.config.FitnessFunc(genes),
+		ga.population[i] = ga.randomIndividual(0, "init")
+	}
+}
+
+// Новая случайная особь, уже оценённая и учтённая в родословной.
+func (ga *GeneticAlgorithm) randomIndividual(generation int, operator string) Individual {
+	var individual Individual
+	if ga.realEncoding() {
+		individual = Individual{RealGenes: ga.randomRealGenes()}
+	} else {
+		individual = Individual{Genes: ga.randomGenes()}
+	}
+
+	ga.evaluate(&individual)
+	ga.track(&individual, generation, operator)
+	return individual
+}
+
+func (ga *GeneticAlgorithm) randomGenes() []byte {
+	length := ga.config.BitsPerGene
+	if ga.config.VariableLength {
+		minGenes, maxGenes := ga.geneLengthBounds()
+		length = minGenes + ga.rng.Intn(maxGenes-minGenes+1)
+	}
+
+	genes := make([]byte, length)
+	for j := 0; j < length; j++ {
+		if ga.rng.Float64() < 0.5 {
+			genes[j] = 1
+		} else {
+			genes[j] = 0
 		}
 	}
+	return genes
 }
 
+const (
+	TerminationMaxGenerations = "max_generations"
+	TerminationStagnation     = "stagnation"
+	TerminationTargetReached  = "target_reached"
+	TerminationTimeBudget     = "time_budget"
+	TerminationCancelled      = "cancelled"
+	TerminationStalled        = "stalled"
+	TerminationInvalidFitness = "invalid_fitness"
+)
+
+// Сколько попыток скрещивания на место в популяции допускается за поколение.
+const maxBreedAttemptsFactor = 10
+
 func (ga *GeneticAlgorithm) Run() (Individual, []float64) {
+	best, convergence, _ := ga.RunWithContext(context.Background())
+	return best, convergence
+}
+
+// Как Run, но перед каждым поколением проверяет ctx. При отмене возвращает
+// лучшую особь завершённых поколений, историю только этих поколений и
+// ctx.Err(). Ошибка возвращается и при срабатывании защиты от зацикливания
+// (см. Err).
+func (ga *GeneticAlgorithm) RunWithContext(ctx context.Context) (Individual, []float64, error) {
+	ga.termination = TerminationMaxGenerations
+	ga.err = nil
+	ga.runStart = time.Now()
 	ga.Initialize()
 
-	for generation := 0; generation < ga.config.MaxGenerations; generation++ {
-		sort.Slice(ga.population, func(i, j int) bool {
-			return ga.population[i].Fitness > ga.population[j].Fitness
-		})
+	binaryGenerations := ga.config.MaxGenerations
+	if ga.twoPhaseEnabled() {
+		binaryGenerations = ga.phaseOneGenerations()
+	}
 
-		ga.bestFitness = append(ga.bestFitness, ga.population[0].Fitness)
+	stagnationBest, stagnantFor := 0.0, 0
+	for generation := 0; generation < binaryGenerations; generation++ {
+		if err := ctx.Err(); err != nil {
+			ga.termination = TerminationCancelled
+			ga.err = err
+			break
+		}
+		if ga.err != nil {
+			break
+		}
+		ga.generation = generation
+		ga.stats.MutationProb = append(ga.stats.MutationProb, ga.decayedMutationProb(ga.config.MutationProb))
 
-		newPopulation := make([]Individual, 0, ga.config.PopulationSize)
+		ga.sortPopulation(ga.population)
 
-		for i := 0; i < ga.config.ElitismCount && i < len(ga.population); i++ {
-			newPopulation = append(newPopulation, ga.population[i])
+		ga.recordGeneration(ga.population)
+		ga.recordImprovement(generation, ga.population[0].Fitness)
+		ga.report(generation)
+		ga.snapshots.push(ga.population)
+
+		distinct := CountDistinct(ga.population)
+		ga.stats.DistinctIndividuals = append(ga.stats.DistinctIndividuals, distinct)
+		ga.stats.Entropy = append(ga.stats.Entropy, PopulationEntropy(ga.population))
+		ga.stats.Diversity = append(ga.stats.Diversity, PopulationDiversity(ga.population))
+		if !ga.notify(generation, ga.population, distinct) {
+			break
+		}
+		if ga.timeBudgetExceeded() {
+			break
 		}
 
+		if generation == 0 || ga.improvement(ga.population[0].Fitness, stagnationBest) > ga.config.ImprovementEpsilon {
+			stagnationBest, stagnantFor = ga.population[0].Fitness, 0
+		} else {
+			stagnantFor++
+		}
+		if ga.config.StagnationLimit > 0 && stagnantFor >= ga.config.StagnationLimit {
+			if ga.config.RestartFraction <= 0 {
+				ga.termination = TerminationStagnation
+				break
+			}
+			ga.restart(generation)
+			stagnationBest, stagnantFor = ga.population[0].Fitness, 0
+		}
+
+		populationMean := meanFitness(ga.population)
+		parentSum := 0.0
+		parentCount := 0
+
+		newPopulation := make([]Individual, 0, ga.config.PopulationSize)
+
+		newPopulation = append(newPopulation, ga.elites()...)
+
+		attempts := 0
 		for len(newPopulation) < ga.config.PopulationSize {
-			parent1 := ga.tournamentSelection()
-			parent2 := ga.tournamentSelection()
+			// Защита от зацикливания, если операторы не дают потомков.
+			attempts++
+			if attempts > maxBreedAttemptsFactor*ga.config.PopulationSize {
+				ga.err = fmt.Errorf("поколение %d: за %d попыток заполнено %d из %d мест популяции",
+					generation, attempts-1, len(newPopulation), ga.config.PopulationSize)
+				ga.termination = TerminationStalled
+				break
+			}
 
-			var child1, child2 Individual
-			if ga.rng.Float64() < ga.config.CrossoverProb {
-				child1, child2 = ga.crossover(parent1, parent2)
-			} else {
-				child1 = parent1
-				child2 = parent2
+			parent1 := ga.selectParent()
+			parent2 := ga.selectParent()
+			parentSum += parent1.Fitness + parent2.Fitness
+			parentCount += 2
+
+			// Просим ровно столько потомков, сколько осталось мест.
+			count := ga.config.PopulationSize - len(newPopulation)
+			if count > 2 {
+				count = 2
 			}
 
-			ga.mutate(&child1)
-			ga.mutate(&child2)
+			newPopulation = append(newPopulation, ga.breed(generation, parent1, parent2, count)...)
+		}
 
-			child1.Fitness = ga.config.FitnessFunc(child1.Genes)
-			child2.Fitness = ga.config.FitnessFunc(child2.Genes)
+		if ga.err != nil {
+			break
+		}
+		ga.population = newPopulation
+		ga.pruneLineage(ga.population)
+
+		differential := 0.0
+		if parentCount > 0 {
+			differential = parentSum/float64(parentCount) - populationMean
+		}
+		ga.stats.SelectionDifferential = append(ga.stats.SelectionDifferential, differential)
+		ga.stats.SelectionResponse = append(ga.stats.SelectionResponse, meanFitness(ga.population)-populationMean)
+	}
+
+	ga.sortPopulation(ga.population)
+	ga.stats.FinalDistinct = CountDistinct(ga.population)
+
+	if ga.twoPhaseEnabled() && ga.termination == TerminationMaxGenerations {
+		// Вторая фаза дописывает историю и может установить ошибку, поэтому
+		// они читаются после неё.
+		best := ga.refineReal(ctx, ga.population[0], ga.config.MaxGenerations-binaryGenerations)
+		return best, ga.bestFitness, ga.err
+	}
 
-			newPopulation = append(newPopulation, child1)
-			if len(newPopulation) < ga.config.PopulationSize {
-				newPopulation = append(newPopulation, child2)
+	return ga.population[0], ga.bestFitness, ga.err
+}
+
+// Скрещивание, мутация и оценка потомков одной пары родителей. Пара даёт
+// max(count, OffspringPerMating) потомков, из которых в популяцию попадают
+// count лучших.
+func (ga *GeneticAlgorithm) breed(generation int, parent1, parent2 Individual, count int) []Individual {
+	produce := count
+	if ga.config.OffspringPerMating > produce {
+		produce = ga.config.OffspringPerMating
+	}
+
+	children := make([]Individual, 0, produce)
+	// Родители каждого потомка для журнала происхождения: оба при
+	// скрещивании, один — тот, с которого снята копия.
+	sources := make([][]int, 0, produce)
+	operator := "copy"
+	if ga.rng.Float64() < ga.config.CrossoverProb {
+		operator = ga.chooseCrossoverType()
+		for len(children) < produce {
+			offspring := ga.crossover(operator, parent1, parent2, produce-len(children))
+			if len(offspring) == 0 {
+				break
 			}
+			children = append(children, offspring...)
+			for range offspring {
+				sources = append(sources, []int{parent1.ID, parent2.ID})
+			}
+		}
+	} else {
+		// Мутация меняет гены на месте, поэтому каждый потомок получает
+		// свою копию: иначе вместе с ним менялся бы родитель (возможно,
+		// из элиты), и его Fitness переставала бы соответствовать генам.
+		parents := []Individual{parent1, parent2}
+		for i := 0; i < produce; i++ {
+			children = append(children, cloneIndividual(parents[i%2]))
+			sources = append(sources, []int{parents[i%2].ID})
 		}
+	}
 
-		ga.population = newPopulation
+	mutated := make([]bool, len(children))
+	for i := range children {
+		mutated[i] = ga.mutate(&children[i])
+		ga.evaluate(&children[i])
 	}
 
-	sort.Slice(ga.population, func(i, j int) bool {
-		return ga.population[i].Fitness > ga.population[j].Fitness
+	if operator != "copy" {
+		ga.creditOperator(operator, parent1, parent2, children...)
+	}
+
+	if len(children) > count {
+		order := make([]int, len(children))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return ga.better(children[order[a]].Fitness, children[order[b]].Fitness)
+		})
+
+		survivors := make([]Individual, count)
+		survivorMutated := make([]bool, count)
+		survivorSources := make([][]int, count)
+		for i := range survivors {
+			survivors[i] = children[order[i]]
+			survivorMutated[i] = mutated[order[i]]
+			survivorSources[i] = sources[order[i]]
+		}
+		children, mutated, sources = survivors, survivorMutated, survivorSources
+	}
+
+	if ga.config.TrackLineage {
+		for i := range children {
+			ga.track(&children[i], generation+1, lineageOperator(operator, mutated[i]), sources[i]...)
+		}
+	}
+
+	return children
+}
+
+// Популяция к этому моменту отсортирована по убыванию приспособленности.
+// При UniqueElites сначала берутся лучшие различные генотипы, а дубликаты —
+// только если различных не хватает.
+func (ga *GeneticAlgorithm) elites() []Individual {
+	count := EliteCount(ga.config)
+	if count > len(ga.population) {
+		count = len(ga.population)
+	}
+	if count <= 0 {
+		return nil
+	}
+	if !ga.config.UniqueElites {
+		return ga.population[:count]
+	}
+
+	elites := make([]Individual, 0, count)
+	taken := make([]bool, len(ga.population))
+	seen := make(map[string]bool)
+	for i, individual := range ga.population {
+		if len(elites) == count {
+			break
+		}
+		key := genotypeKey(individual)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		taken[i] = true
+		elites = append(elites, individual)
+	}
+
+	for i := 0; len(elites) < count && i < len(ga.population); i++ {
+		if !taken[i] {
+			elites = append(elites, ga.population[i])
+		}
+	}
+	return elites
+}
+
+func (ga *GeneticAlgorithm) evaluate(individual *Individual) {
+	if ga.cachedEvaluate(individual) {
+		return
+	}
+
+	if ga.config.TimePenaltyFactor == 0 {
+		individual.Fitness = ga.sampleFitness(individual)
+	} else {
+		start := time.Now()
+		individual.Fitness = ga.sampleFitness(individual)
+		individual.EvalCost = float64(time.Since(start).Nanoseconds()) / 1e6
+	}
+	ga.checkFitness(individual)
+	ga.storeFitness(*individual)
+}
+
+func (ga *GeneticAlgorithm) sampleFitness(individual *Individual) float64 {
+	fitness := func() float64 {
+		// Вещественные гены есть и у особей второй фазы двухфазного режима.
+		if ga.realEncoding() || individual.RealGenes != nil {
+			return ga.realFitness(individual.RealGenes)
+		}
+		return ga.config.FitnessFunc(individual.Genes)
+	}
+
+	samples := ga.config.FitnessSamples
+	if samples <= 1 {
+		ga.stats.FitnessEvaluations++
+		return fitness()
+	}
+
+	ga.stats.FitnessEvaluations += samples
+
+	sum := 0.0
+	for i := 0; i < samples; i++ {
+		sum += fitness()
+	}
+	return sum / float64(samples)
+}
+
+// На сколько a лучше b (отрицательно, если хуже).
+func (ga *GeneticAlgorithm) improvement(a, b float64) float64 {
+	if ga.config.Minimize {
+		return b - a
+	}
+	return a - b
+}
+
+// Лучше ли приспособленность a, чем b, с учётом направления оптимизации.
+func (ga *GeneticAlgorithm) better(a, b float64) bool {
+	if ga.config.Minimize {
+		return a < b
+	}
+	return a > b
+}
+
+// Упорядочивает популяцию от лучшей особи к худшей. При равной
+// приспособленности раньше идёт особь с лексикографически меньшими генами
+// (Genes, затем RealGenes), поэтому порядок, а с ним элита, лучшая особь
+// и кривые сходимости, не зависит от порядка особей на входе и от
+// реализации сортировки.
+func (ga *GeneticAlgorithm) sortPopulation(population []Individual) {
+	sort.SliceStable(population, func(i, j int) bool {
+		a, b := population[i], population[j]
+		if a.Fitness != b.Fitness {
+			return ga.better(a.Fitness, b.Fitness)
+		}
+		return compareGenotypes(a, b) < 0
 	})
+}
 
-	return ga.population[0], ga.bestFitness
+// Порядок генотипов для разрешения ничьих: вещественные гены, если они
+// есть хотя бы у одной особи, сравниваются первыми (двоичные гены
+// вещественной особи лишь округляют их), затем двоичные —
+// лексикографически.
+func compareGenotypes(a, b Individual) int {
+	if len(a.RealGenes) > 0 || len(b.RealGenes) > 0 {
+		if c := slices.Compare(a.RealGenes, b.RealGenes); c != 0 {
+			return c
+		}
+	}
+	return bytes.Compare(a.Genes, b.Genes)
 }
 
+func (ga *GeneticAlgorithm) report(generation int) {
+	if ga.config.ReportWriter == nil {
+		return
+	}
+
+	interval := ga.config.ReportInterval
+	if interval <= 0 {
+		interval = 1
+	}
+	if generation%interval != 0 && generation != ga.config.MaxGenerations-1 {
+		return
+	}
+
+	fmt.Fprintf(ga.config.ReportWriter, "Поколение %d/%d: лучшая приспособленность=%.6f\n",
+		generation+1, ga.config.MaxGenerations, ga.population[0].Fitness)
+}
+
+// При равной приспособленности побеждает особь с меньшим EvalCost (если
+// TimePenaltyFactor > 0), затем лексикографически меньший генотип, чтобы
+// исход турнира не зависел от порядка, в котором вытянуты участники.
 func (ga *GeneticAlgorithm) tournamentSelection() Individual {
-	tournamentSize := 3
+	tournamentSize := ga.config.TournamentSize
+	if tournamentSize <= 0 {
+		tournamentSize = 3
+	}
+	if tournamentSize > len(ga.population) {
+		tournamentSize = len(ga.population)
+	}
 	best := ga.population[ga.rng.Intn(len(ga.population))]
 
 	for i := 1; i < tournamentSize; i++ {
 		candidate := ga.population[ga.rng.Intn(len(ga.population))]
-		if candidate.Fitness > best.Fitness {
+		if ga.tournamentWins(candidate, best) {
 			best = candidate
 		}
 	}
@@ -120,14 +680,71 @@ func (ga *GeneticAlgorithm) tournamentSelection() Individual {
 	return best
 }
 
-func (ga *GeneticAlgorithm) crossover(parent1, parent2 Individual) (Individual, Individual) {
-	if ga.config.CrossoverType == "onepoint" {
-		return ga.onepointCrossover(parent1, parent2)
+func (ga *GeneticAlgorithm) tournamentWins(candidate, best Individual) bool {
+	if candidate.Fitness != best.Fitness {
+		return ga.better(candidate.Fitness, best.Fitness)
 	}
-	return ga.uniformCrossover(parent1, parent2)
+	if ga.config.TimePenaltyFactor > 0 && candidate.EvalCost != best.EvalCost {
+		return candidate.EvalCost < best.EvalCost
+	}
+	return compareGenotypes(candidate, best) < 0
+}
+
+// Возвращает от одного до count потомков. Одиночные операторы
+// (arithmetic) всегда дают одного потомка; парные при count == 1 отдают
+// только первого.
+func (ga *GeneticAlgorithm) crossover(crossoverType string, parent1, parent2 Individual, count int) []Individual {
+	var child1, child2 Individual
+	switch {
+	case ga.realEncoding():
+		child1, child2 = ga.blendCrossover(parent1, parent2)
+	default:
+		child1, child2 = ga.binaryCrossover(crossoverType, parent1, parent2)
+		if crossoverType == "arithmetic" {
+			return []Individual{child1}
+		}
+	}
+
+	if count < 2 {
+		return []Individual{child1}
+	}
+	return []Individual{child1, child2}
+}
+
+// Двоичные операторы; у arithmetic второй потомок пустой.
+func (ga *GeneticAlgorithm) binaryCrossover(crossoverType string, parent1, parent2 Individual) (child1, child2 Individual) {
+	switch crossoverType {
+	case "arithmetic":
+		child1 = ga.arithmeticCrossover(parent1, parent2)
+	case "onepoint":
+		child1, child2 = ga.onepointCrossover(parent1, parent2)
+	case "twopoint":
+		child1, child2 = ga.twopointCrossover(parent1, parent2)
+	default:
+		child1, child2 = ga.uniformCrossover(parent1, parent2)
+	}
+	return child1, child2
+}
+
+// Арифметическое скрещивание: хромосомы читаются как числа из [0, 1],
+// потомок — их случайная выпуклая комбинация. При разной длине родителей
+// сводится к первому потомку cut-and-splice.
+func (ga *GeneticAlgorithm) arithmeticCrossover(parent1, parent2 Individual) Individual {
+	if len(parent1.Genes) != len(parent2.Genes) {
+		child, _ := ga.cutAndSpliceCrossover(parent1, parent2)
+		return child
+	}
+
+	alpha := ga.rng.Float64()
+	x := alpha*BytesToFloat(parent1.Genes, 0, 1) + (1-alpha)*BytesToFloat(parent2.Genes, 0, 1)
+	return Individual{Genes: FloatToBytes(x, 0, 1, len(parent1.Genes))}
 }
 
 func (ga *GeneticAlgorithm) onepointCrossover(parent1, parent2 Individual) (Individual, Individual) {
+	if len(parent1.Genes) != len(parent2.Genes) {
+		return ga.cutAndSpliceCrossover(parent1, parent2)
+	}
+
 	point := ga.rng.Intn(len(parent1.Genes))
 
 	child1Genes := make([]byte, len(parent1.Genes))
@@ -142,12 +759,68 @@ func (ga *GeneticAlgorithm) onepointCrossover(parent1, parent2 Individual) (Indi
 	return Individual{Genes: child1Genes}, Individual{Genes: child2Genes}
 }
 
+// Двухточечное скрещивание: потомки обмениваются отрезком [a, b) между
+// двумя различными точками разреза. При совпадении точек или хромосомах
+// короче двух генов потомки — копии родителей.
+func (ga *GeneticAlgorithm) twopointCrossover(parent1, parent2 Individual) (Individual, Individual) {
+	if len(parent1.Genes) != len(parent2.Genes) {
+		return ga.cutAndSpliceCrossover(parent1, parent2)
+	}
+
+	child1Genes := append([]byte(nil), parent1.Genes...)
+	child2Genes := append([]byte(nil), parent2.Genes...)
+	if len(parent1.Genes) < 2 {
+		return Individual{Genes: child1Genes}, Individual{Genes: child2Genes}
+	}
+
+	a := ga.rng.Intn(len(parent1.Genes))
+	b := ga.rng.Intn(len(parent1.Genes))
+	if a > b {
+		a, b = b, a
+	}
+
+	copy(child1Genes[a:b], parent2.Genes[a:b])
+	copy(child2Genes[a:b], parent1.Genes[a:b])
+
+	return Individual{Genes: child1Genes}, Individual{Genes: child2Genes}
+}
+
+// Cut-and-splice: у каждого родителя своя точка разреза, поэтому длины
+// потомков могут отличаться от длин родителей.
+func (ga *GeneticAlgorithm) cutAndSpliceCrossover(parent1, parent2 Individual) (Individual, Individual) {
+	point1 := ga.rng.Intn(len(parent1.Genes) + 1)
+	point2 := ga.rng.Intn(len(parent2.Genes) + 1)
+
+	child1Genes := make([]byte, 0, point1+len(parent2.Genes)-point2)
+	child1Genes = append(child1Genes, parent1.Genes[:point1]...)
+	child1Genes = append(child1Genes, parent2.Genes[point2:]...)
+
+	child2Genes := make([]byte, 0, point2+len(parent1.Genes)-point1)
+	child2Genes = append(child2Genes, parent2.Genes[:point2]...)
+	child2Genes = append(child2Genes, parent1.Genes[point1:]...)
+
+	return Individual{Genes: ga.clampLength(child1Genes)}, Individual{Genes: ga.clampLength(child2Genes)}
+}
+
+// При разной длине родителей обмен идёт по общей части,
+// хвост каждый потомок наследует от «своего» родителя.
 func (ga *GeneticAlgorithm) uniformCrossover(parent1, parent2 Individual) (Individual, Individual) {
 	child1Genes := make([]byte, len(parent1.Genes))
 	child2Genes := make([]byte, len(parent2.Genes))
+	copy(child1Genes, parent1.Genes)
+	copy(child2Genes, parent2.Genes)
 
-	for i := 0; i < len(parent1.Genes); i++ {
-		if ga.rng.Float64() < 0.5 {
+	common := len(parent1.Genes)
+	if len(parent2.Genes) < common {
+		common = len(parent2.Genes)
+	}
+
+	ratio := ga.config.UniformMixRatio
+	if ratio == 0 {
+		ratio = 0.5
+	}
+	for i := 0; i < common; i++ {
+		if ga.rng.Float64() < ratio {
 			child1Genes[i] = parent1.Genes[i]
 			child2Genes[i] = parent2.Genes[i]
 		} else {
@@ -159,39 +832,368 @@ func (ga *GeneticAlgorithm) uniformCrossover(parent1, parent2 Individual) (Indiv
 	return Individual{Genes: child1Genes}, Individual{Genes: child2Genes}
 }
 
-func (ga *GeneticAlgorithm) mutate(individual *Individual) {
+func (ga *GeneticAlgorithm) mutate(individual *Individual) bool {
+	if ga.realEncoding() {
+		return ga.mutateReal(individual)
+	}
+	if ga.config.MutationType == "swap" {
+		return ga.swapMutation(individual)
+	}
+
+	mutated := false
 	for i := 0; i < len(individual.Genes); i++ {
-		if ga.rng.Float64() < ga.config.MutationProb {
+		if ga.rng.Float64() < ga.bitMutationProb(i, len(individual.Genes)) {
 			if individual.Genes[i] == 0 {
 				individual.Genes[i] = 1
 			} else {
 				individual.Genes[i] = 0
 			}
+			mutated = true
 		}
 	}
+
+	if ga.config.VariableLength && ga.mutateLength(individual) {
+		mutated = true
+	}
+	return mutated
 }
 
+// Меняет местами гены в двух различных позициях. Возвращает true, только
+// если хромосома действительно изменилась (значения в позициях различны).
+func (ga *GeneticAlgorithm) swapMutation(individual *Individual) bool {
+	genes := individual.Genes
+	if len(genes) < 2 || ga.rng.Float64() >= ga.decayedMutationProb(ga.config.MutationProb) {
+		return false
+	}
+
+	i := ga.rng.Intn(len(genes))
+	j := ga.rng.Intn(len(genes) - 1)
+	if j >= i {
+		j++
+	}
+	// Копия при записи, как в mutateReal: гены могут разделяться с
+	// родителем или особью элиты.
+	genes = append([]byte(nil), genes...)
+	genes[i], genes[j] = genes[j], genes[i]
+	individual.Genes = genes
+	return genes[i] != genes[j]
+}
+
+func (ga *GeneticAlgorithm) geneLengthBounds() (int, int) {
+	minGenes, maxGenes := ga.config.MinGenes, ga.config.MaxGenes
+	if minGenes <= 0 {
+		minGenes = 1
+	}
+	if maxGenes <= 0 {
+		maxGenes = ga.config.BitsPerGene
+	}
+	if maxGenes < minGenes {
+		maxGenes = minGenes
+	}
+	return minGenes, maxGenes
+}
+
+func (ga *GeneticAlgorithm) clampLength(genes []byte) []byte {
+	if !ga.config.VariableLength {
+		return genes
+	}
+
+	minGenes, maxGenes := ga.geneLengthBounds()
+	if len(genes) > maxGenes {
+		genes = genes[:maxGenes]
+	}
+	for len(genes) < minGenes {
+		genes = append(genes, byte(ga.rng.Intn(2)))
+	}
+	return genes
+}
+
+// Вставка и удаление создают новый срез, чтобы не затронуть гены
+// родителя, скопированного без скрещивания.
+func (ga *GeneticAlgorithm) mutateLength(individual *Individual) bool {
+	minGenes, maxGenes := ga.geneLengthBounds()
+	changed := false
+
+	if len(individual.Genes) < maxGenes && ga.rng.Float64() < ga.config.InsertProb {
+		pos := ga.rng.Intn(len(individual.Genes) + 1)
+		genes := make([]byte, 0, len(individual.Genes)+1)
+		genes = append(genes, individual.Genes[:pos]...)
+		genes = append(genes, byte(ga.rng.Intn(2)))
+		genes = append(genes, individual.Genes[pos:]...)
+		individual.Genes = genes
+		changed = true
+	}
+
+	if len(individual.Genes) > minGenes && ga.rng.Float64() < ga.config.DeleteProb {
+		pos := ga.rng.Intn(len(individual.Genes))
+		genes := make([]byte, 0, len(individual.Genes)-1)
+		genes = append(genes, individual.Genes[:pos]...)
+		genes = append(genes, individual.Genes[pos+1:]...)
+		individual.Genes = genes
+		changed = true
+	}
+
+	return changed
+}
+
+// Для boundarylocal вероятность линейно убывает от младшего бита (i = 0)
+// к старшему, средняя по всем битам остаётся равной MutationProb.
+func (ga *GeneticAlgorithm) bitMutationProb(bit, length int) float64 {
+	if ga.config.MutationRates != nil && bit < len(ga.config.MutationRates) {
+		return ga.decayedMutationProb(ga.config.MutationRates[bit])
+	}
+	mutationProb := ga.decayedMutationProb(ga.config.MutationProb)
+	if ga.config.MutationType != "boundarylocal" || length < 2 {
+		return mutationProb
+	}
+
+	bias := math.Max(0, math.Min(1, ga.config.BitSignificanceBias))
+	significance := float64(bit) / float64(length-1)
+	return mutationProb * (1 - bias*significance) / (1 - bias/2)
+}
+
+// Наибольшая длина хромосомы, которую декодируют BytesToInt, BytesToFloat
+// и их варианты для кода Грея: значение должно помещаться в int64 без знака.
+const MaxDecodeBits = 63
+
+// Порядок битов во всех декодерах — от младшего к старшему: ген i
+// соответствует 1<<i. Хромосомы длиннее MaxDecodeBits вызывают панику;
+// на 32-битных платформах результат больше 31 бита не помещается в int,
+// поэтому там для длинных хромосом следует использовать BytesToFloat.
 func BytesToInt(genes []byte) int {
-	result := 0
+	return int(bytesToUint64(genes))
+}
+
+func bytesToUint64(genes []byte) uint64 {
+	if len(genes) > MaxDecodeBits {
+		panic(fmt.Sprintf("ga: хромосома из %d генов длиннее MaxDecodeBits = %d", len(genes), MaxDecodeBits))
+	}
+
+	var result uint64
 	for i := 0; i < len(genes); i++ {
 		if genes[i] == 1 {
-			result |= (1 << i)
+			result |= 1 << i
 		}
 	}
 	return result
 }
 
+// Наибольшее значение хромосомы из bits генов, 2^bits - 1.
+func maxDecodedValue(bits int) uint64 {
+	return 1<<bits - 1
+}
+
+// Обратное к BytesToFloat: ближайшее представимое значение на сетке из
+// 2^bits точек.
+func FloatToBytes(value, min, max float64, bits int) []byte {
+	genes := make([]byte, bits)
+	if bits == 0 || max == min {
+		return genes
+	}
+
+	if bits > MaxDecodeBits {
+		panic(fmt.Sprintf("ga: длина хромосомы %d больше MaxDecodeBits = %d", bits, MaxDecodeBits))
+	}
+
+	maxInt := maxDecodedValue(bits)
+	normalized := math.Max(0, math.Min(1, (value-min)/(max-min)))
+	// При длине, близкой к 63, float64(maxInt) округляется вверх до 2^bits.
+	intVal := uint64(math.Round(normalized * float64(maxInt)))
+	if intVal > maxInt {
+		intVal = maxInt
+	}
+	for i := 0; i < bits; i++ {
+		if intVal&(1<<i) != 0 {
+			genes[i] = 1
+		}
+	}
+	return genes
+}
+
 func BytesToFloat(genes []byte, min, max float64) float64 {
-	intVal := BytesToInt(genes)
-	maxInt := (1 << len(genes)) - 1
-	normalized := float64(intVal) / float64(maxInt)
+	if len(genes) == 0 {
+		return min
+	}
+	intVal := bytesToUint64(genes)
+	normalized := float64(intVal) / float64(maxDecodedValue(len(genes)))
 	return min + normalized*(max-min)
 }
 
+func HammingDistance(a, b []byte) (int, error) {
+	if len(a) != len(b) {
+		return 0, fmt.Errorf("разная длина генов: %d и %d", len(a), len(b))
+	}
+
+	distance := 0
+	for i := 0; i < len(a); i++ {
+		if a[i] != b[i] {
+			distance++
+		}
+	}
+	return distance, nil
+}
+
+// Гены делятся на len(bounds) равных участков, каждый декодируется
+// в свой диапазон [min, max]; без bounds — одна координата в [0, 1].
+// При несовпадении длин или разбиения возвращается NaN.
+func EuclideanDistance(a, b []byte, bounds ...[2]float64) float64 {
+	if len(bounds) == 0 {
+		bounds = [][2]float64{{0, 1}}
+	}
+	if len(a) != len(b) || len(a)%len(bounds) != 0 || len(a) == 0 {
+		return math.NaN()
+	}
+
+	width := len(a) / len(bounds)
+	sum := 0.0
+	for d, bound := range bounds {
+		from, to := d*width, (d+1)*width
+		x := BytesToFloat(a[from:to], bound[0], bound[1])
+		y := BytesToFloat(b[from:to], bound[0], bound[1])
+		sum += (x - y) * (x - y)
+	}
+	return math.Sqrt(sum)
+}
+
+// Истекло ли MaxDuration с начала Run; если да, отмечает причину остановки.
+func (ga *GeneticAlgorithm) timeBudgetExceeded() bool {
+	if ga.config.MaxDuration <= 0 || time.Since(ga.runStart) < ga.config.MaxDuration {
+		return false
+	}
+	ga.termination = TerminationTimeBudget
+	return true
+}
+
+// Ошибка последнего Run: непустая, если запуск прерван защитой от
+// зацикливания (TerminationStalled), некорректной приспособленностью
+// (TerminationInvalidFitness) или отменой контекста RunWithContext.
+// Результат Run тогда — лучшая особь последнего полного поколения.
+func (ga *GeneticAlgorithm) Err() error {
+	return ga.err
+}
+
+// Причина остановки последнего Run (одна из констант Termination*).
+func (ga *GeneticAlgorithm) TerminationReason() string {
+	return ga.termination
+}
+
+func (ga *GeneticAlgorithm) Stats() RunStats {
+	return ga.stats
+}
+
+func CountDistinct(population []Individual) int {
+	seen := make(map[string]struct{}, len(population))
+	for _, individual := range population {
+		seen[genotypeKey(individual)] = struct{}{}
+	}
+	return len(seen)
+}
+
+// Средняя по локусам энтропия Шеннона (в битах, от 0 до 1). Для хромосом
+// разной длины локус учитывается по тем особям, у которых он есть.
+func PopulationEntropy(population []Individual) float64 {
+	loci := 0
+	for _, individual := range population {
+		if len(individual.Genes) > loci {
+			loci = len(individual.Genes)
+		}
+	}
+	if loci == 0 {
+		return 0
+	}
+
+	total := 0.0
+	for locus := 0; locus < loci; locus++ {
+		ones, present := 0, 0
+		for _, individual := range population {
+			if locus < len(individual.Genes) {
+				present++
+				if individual.Genes[locus] == 1 {
+					ones++
+				}
+			}
+		}
+
+		p := float64(ones) / float64(present)
+		if p > 0 && p < 1 {
+			total -= p*math.Log2(p) + (1-p)*math.Log2(1-p)
+		}
+	}
+	return total / float64(loci)
+}
+
+// Разнообразие популяции в [0, 1] — среднее попарное расстояние Хэмминга,
+// нормированное на наибольшее возможное при данном числе особей n (в каждом
+// локусе поровну нулей и единиц). 0 — все генотипы совпадают, у случайной
+// популяции около (n-1)/n. Хромосомы разной длины сравниваются по общей
+// части, вещественные гены не учитываются.
+func PopulationDiversity(population []Individual) float64 {
+	n := len(population)
+	if n < 2 {
+		return 0
+	}
+	loci := len(population[0].Genes)
+	for _, individual := range population {
+		loci = min(loci, len(individual.Genes))
+	}
+	if loci == 0 {
+		return 0
+	}
+
+	// Пар, различающихся в локусе, ровно ones·(n-ones).
+	differing := 0.0
+	for locus := 0; locus < loci; locus++ {
+		ones := 0
+		for _, individual := range population {
+			if individual.Genes[locus] == 1 {
+				ones++
+			}
+		}
+		differing += float64(ones) * float64(n-ones)
+	}
+	// Различающихся пар больше всего, ⌊n/2⌋·⌈n/2⌉, при поровну нулей и единиц.
+	maxDiffering := float64(n/2) * float64(n-n/2)
+	return differing / maxDiffering / float64(loci)
+}
+
+func meanFitness(population []Individual) float64 {
+	if len(population) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, individual := range population {
+		sum += individual.Fitness
+	}
+	return sum / float64(len(population))
+}
+
 func (ga *GeneticAlgorithm) GetBestFitnessHistory() []float64 {
 	return ga.bestFitness
 }
 
+// Запоминает лучшую, среднюю приспособленность и разброс поколения;
+// population отсортирована от лучшей особи к худшей.
+func (ga *GeneticAlgorithm) recordGeneration(population []Individual) {
+	fitnesses := make([]float64, len(population))
+	for i, individual := range population {
+		fitnesses[i] = individual.Fitness
+	}
+	mean := meanFitness(population)
+
+	ga.bestFitness = append(ga.bestFitness, population[0].Fitness)
+	ga.stats.MeanFitness = append(ga.stats.MeanFitness, mean)
+	ga.stats.StdDevFitness = append(ga.stats.StdDevFitness, StdDev(fitnesses, mean))
+}
+
+// История сходимости последнего Run.
+func (ga *GeneticAlgorithm) ConvergenceHistory() ConvergenceHistory {
+	return ConvergenceHistory{
+		Best:   ga.bestFitness,
+		Mean:   ga.stats.MeanFitness,
+		StdDev: ga.stats.StdDevFitness,
+	}
+}
+
 func StdDev(values []float64, mean float64) float64 {
 	sum := 0.0
 	for _, v := range values {
@@ -0,0 +1,475 @@
+package ga
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+// Потомки, скопированные без скрещивания, мутируют свою копию генов:
+// приспособленность родителей (и элиты) должна оставаться верной.
+func TestBestFitnessMatchesGenes(t *testing.T) {
+	for _, mutationType := range []string{"bitflip", "swap"} {
+		for seed := int64(1); seed <= 50; seed++ {
+			algorithm := NewGeneticAlgorithm(Config{
+				PopulationSize: 20,
+				MaxGenerations: 15,
+				CrossoverProb:  0.3,
+				MutationProb:   0.2,
+				ElitismCount:   2,
+				BitsPerGene:    16,
+				MutationType:   mutationType,
+				FitnessFunc: func(genes []byte) float64 {
+					// Несимметричная функция, чтобы перестановка генов
+					// меняла приспособленность.
+					return float64(BytesToInt(genes))
+				},
+				Seed: seed,
+			})
+			best, _ := algorithm.Run()
+			if got := float64(BytesToInt(best.Genes)); got != best.Fitness {
+				t.Fatalf("%s, зерно %d: Fitness лучшей особи %v, а её гены дают %v",
+					mutationType, seed, best.Fitness, got)
+			}
+		}
+	}
+}
+
+func TestSwapMutationChangesTwoPositions(t *testing.T) {
+	algorithm := NewGeneticAlgorithm(Config{
+		PopulationSize: 2,
+		MutationProb:   1,
+		BitsPerGene:    32,
+		MutationType:   "swap",
+		FitnessFunc:    func([]byte) float64 { return 0 },
+		Seed:           7,
+	})
+
+	original := make([]byte, 32)
+	for i := range original {
+		original[i] = byte(i % 2)
+	}
+	individual := Individual{Genes: original}
+	for attempt := 0; attempt < 100; attempt++ {
+		shared := individual.Genes
+		if !algorithm.swapMutation(&individual) {
+			continue
+		}
+
+		changed := 0
+		ones := 0
+		for i := range original {
+			if individual.Genes[i] != shared[i] {
+				changed++
+			}
+			ones += int(individual.Genes[i])
+		}
+		if changed != 2 {
+			t.Fatalf("попытка %d: изменилось %d позиций, ожидалось 2", attempt, changed)
+		}
+		if ones != 16 {
+			t.Fatalf("попытка %d: перестановка изменила набор значений генов: %d единиц из 32", attempt, ones)
+		}
+	}
+	for i := range original {
+		if original[i] != byte(i%2) {
+			t.Fatalf("swapMutation изменила исходный срез генов в позиции %d", i)
+		}
+	}
+}
+
+func randomBits(rng Rand, n int) []byte {
+	genes := make([]byte, n)
+	for i := range genes {
+		genes[i] = byte(rng.Intn(2))
+	}
+	return genes
+}
+
+// Расстояние Хэмминга — метрика: ноль до себя, симметрия, неравенство
+// треугольника; при разной длине — ошибка.
+func TestHammingDistanceIsMetric(t *testing.T) {
+	rng := NewRand("", 11)
+	for trial := 0; trial < 200; trial++ {
+		a, b, c := randomBits(rng, 24), randomBits(rng, 24), randomBits(rng, 24)
+
+		if d, err := HammingDistance(a, a); err != nil || d != 0 {
+			t.Fatalf("d(a, a) = %d, %v", d, err)
+		}
+		ab, _ := HammingDistance(a, b)
+		ba, _ := HammingDistance(b, a)
+		bc, _ := HammingDistance(b, c)
+		ac, _ := HammingDistance(a, c)
+		if ab != ba {
+			t.Fatalf("d(a, b) = %d, d(b, a) = %d", ab, ba)
+		}
+		if ac > ab+bc {
+			t.Fatalf("d(a, c) = %d > d(a, b) + d(b, c) = %d + %d", ac, ab, bc)
+		}
+	}
+
+	if d, _ := HammingDistance([]byte{0, 1, 1, 0}, []byte{1, 1, 0, 0}); d != 2 {
+		t.Fatalf("d = %d, ожидалось 2", d)
+	}
+	if _, err := HammingDistance([]byte{0, 1}, []byte{0}); err == nil {
+		t.Fatal("нет ошибки при разной длине генов")
+	}
+}
+
+// Евклидово расстояние декодированных координат — метрика; при
+// несовпадении длины или разбиения — NaN.
+func TestEuclideanDistanceIsMetric(t *testing.T) {
+	bounds := [][2]float64{{-5, 5}, {0, 1}, {10, 20}}
+	rng := NewRand("", 12)
+	for trial := 0; trial < 200; trial++ {
+		a, b, c := randomBits(rng, 24), randomBits(rng, 24), randomBits(rng, 24)
+
+		if d := EuclideanDistance(a, a, bounds...); d != 0 {
+			t.Fatalf("d(a, a) = %v", d)
+		}
+		ab := EuclideanDistance(a, b, bounds...)
+		if ba := EuclideanDistance(b, a, bounds...); ab != ba {
+			t.Fatalf("d(a, b) = %v, d(b, a) = %v", ab, ba)
+		}
+		bc := EuclideanDistance(b, c, bounds...)
+		if ac := EuclideanDistance(a, c, bounds...); ac > ab+bc+1e-12 {
+			t.Fatalf("d(a, c) = %v > d(a, b) + d(b, c) = %v + %v", ac, ab, bc)
+		}
+	}
+
+	// Без bounds — одна координата в [0, 1]: 1111 против 0000.
+	if d := EuclideanDistance([]byte{1, 1, 1, 1}, []byte{0, 0, 0, 0}); d != 1 {
+		t.Fatalf("d = %v, ожидалось 1", d)
+	}
+	if d := EuclideanDistance(make([]byte, 4), make([]byte, 4), bounds...); !math.IsNaN(d) {
+		t.Fatalf("4 бита на 3 координаты: d = %v, ожидалось NaN", d)
+	}
+	if d := EuclideanDistance(make([]byte, 3), make([]byte, 6), bounds...); !math.IsNaN(d) {
+		t.Fatalf("разная длина: d = %v, ожидалось NaN", d)
+	}
+}
+
+// Бит с вероятностью 0 не мутирует никогда, с вероятностью 1 — всегда,
+// остальные — с частотой около своей вероятности; MutationProb при этом
+// не используется.
+func TestMutationRatesArePerLocus(t *testing.T) {
+	rates := []float64{0, 1, 0.25, 0, 1, 0.25, 0, 1}
+	config := validConfig()
+	config.MutationProb = 0.5
+	config.MutationRates = rates
+	config.Seed = 13
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	algorithm := NewGeneticAlgorithm(config)
+
+	const trials = 4000
+	flips := make([]int, len(rates))
+	for trial := 0; trial < trials; trial++ {
+		individual := Individual{Genes: make([]byte, len(rates))}
+		algorithm.mutate(&individual)
+		for i, gene := range individual.Genes {
+			flips[i] += int(gene)
+		}
+	}
+
+	for i, rate := range rates {
+		switch rate {
+		case 0, 1:
+			if want := int(rate * trials); flips[i] != want {
+				t.Fatalf("бит %d с вероятностью %v мутировал %d раз из %d", i, rate, flips[i], trials)
+			}
+		default:
+			if got := float64(flips[i]) / trials; math.Abs(got-rate) > 0.03 {
+				t.Fatalf("бит %d: частота мутации %.3f, ожидалось около %v", i, got, rate)
+			}
+		}
+	}
+}
+
+// При Minimize ГА спускается ко дну параболы (x − 1.7)² на [−5, 5], а без
+// него уходит к дальнему краю отрезка.
+func TestMinimizeQuadraticBowl(t *testing.T) {
+	const minimum = 1.7
+	config := validConfig()
+	config.PopulationSize = 40
+	config.MaxGenerations = 60
+	config.MutationProb = 0.02
+	config.BitsPerGene = 16
+	config.Seed = 21
+	config.FitnessFunc = func(genes []byte) float64 {
+		x := BytesToFloat(genes, -5, 5)
+		return (x - minimum) * (x - minimum)
+	}
+
+	config.Minimize = true
+	best, history := NewGeneticAlgorithm(config).Run()
+	if x := BytesToFloat(best.Genes, -5, 5); math.Abs(x-minimum) > 0.05 {
+		t.Fatalf("минимум найден в x = %v (f = %v), ожидалось около %v", x, best.Fitness, minimum)
+	}
+	for i := 1; i < len(history); i++ {
+		if history[i] > history[i-1] {
+			t.Fatalf("с элитой лучшее значение выросло в поколении %d: %v → %v", i, history[i-1], history[i])
+		}
+	}
+
+	config.Minimize = false
+	best, _ = NewGeneticAlgorithm(config).Run()
+	if x := BytesToFloat(best.Genes, -5, 5); x > -4.9 {
+		t.Fatalf("при максимизации x = %v, ожидалось около −5", x)
+	}
+}
+
+// На плоском ландшафте запуск останавливается через StagnationLimit
+// поколений без улучшения, история содержит только выполненные поколения;
+// при непрерывном улучшении запуск доходит до MaxGenerations.
+func TestStagnationStopsEarly(t *testing.T) {
+	config := validConfig()
+	config.MaxGenerations = 50
+	config.StagnationLimit = 5
+
+	algorithm := NewGeneticAlgorithm(config)
+	_, history := algorithm.Run()
+	if algorithm.TerminationReason() != TerminationStagnation {
+		t.Fatalf("причина остановки %q, ожидалась %q", algorithm.TerminationReason(), TerminationStagnation)
+	}
+	if want := config.StagnationLimit + 1; len(history) != want {
+		t.Fatalf("история из %d поколений, ожидалось %d", len(history), want)
+	}
+
+	// Каждая новая оценка больше всех предыдущих.
+	calls := 0
+	config.FitnessFunc = func([]byte) float64 {
+		calls++
+		return float64(calls)
+	}
+	algorithm = NewGeneticAlgorithm(config)
+	_, history = algorithm.Run()
+	if algorithm.TerminationReason() != TerminationMaxGenerations || len(history) != config.MaxGenerations {
+		t.Fatalf("при улучшении: причина %q, %d поколений из %d",
+			algorithm.TerminationReason(), len(history), config.MaxGenerations)
+	}
+
+	// ImprovementEpsilon: улучшения меньше порога не считаются.
+	config.ImprovementEpsilon = 1e9
+	algorithm = NewGeneticAlgorithm(config)
+	if _, history = algorithm.Run(); algorithm.TerminationReason() != TerminationStagnation {
+		t.Fatalf("улучшения меньше ImprovementEpsilon прервали застой: %d поколений", len(history))
+	}
+}
+
+// Отмена контекста останавливает запуск перед следующим поколением:
+// история содержит только завершённые поколения, возвращается ctx.Err().
+func TestRunWithContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const cancelAt = 3
+	config := validConfig()
+	config.MaxGenerations = 1000
+	config.OnGeneration = func(gen int, _ GenerationStats) {
+		if gen == cancelAt {
+			cancel()
+		}
+	}
+	algorithm := NewGeneticAlgorithm(config)
+	best, history, err := algorithm.RunWithContext(ctx)
+	if !errors.Is(err, context.Canceled) || !errors.Is(algorithm.Err(), context.Canceled) {
+		t.Fatalf("ошибка %v (Err() = %v), ожидалась context.Canceled", err, algorithm.Err())
+	}
+	if algorithm.TerminationReason() != TerminationCancelled {
+		t.Fatalf("причина остановки %q, ожидалась %q", algorithm.TerminationReason(), TerminationCancelled)
+	}
+	if len(history) != cancelAt+1 {
+		t.Fatalf("история из %d поколений, завершено %d", len(history), cancelAt+1)
+	}
+	if best.Genes == nil {
+		t.Fatal("не возвращена лучшая особь завершённых поколений")
+	}
+
+	// Медленная функция и истёкший срок: запуск завершается сразу, а не
+	// через тысячу поколений.
+	config.OnGeneration = nil
+	config.FitnessFunc = func([]byte) float64 {
+		time.Sleep(100 * time.Microsecond)
+		return 0
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, history, err = NewGeneticAlgorithm(config).RunWithContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("ошибка %v, ожидалась context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second || len(history) >= config.MaxGenerations {
+		t.Fatalf("остановка через %v и %d поколений", elapsed, len(history))
+	}
+}
+
+func allOnes(n int) []byte {
+	genes := make([]byte, n)
+	for i := range genes {
+		genes[i] = 1
+	}
+	return genes
+}
+
+// Декодирование на границе допустимой длины: 62 и 63 бита читаются
+// точно, старший бит не теряется, длиннее MaxDecodeBits — паника.
+func TestBytesToIntAtMaxLength(t *testing.T) {
+	tests := []struct {
+		genes []byte
+		want  int
+	}{
+		{allOnes(62), 1<<62 - 1},
+		{allOnes(63), math.MaxInt64},
+		{append(make([]byte, 61), 1), 1 << 61},
+		{append(make([]byte, 62), 1), 1 << 62},
+		{append([]byte{1}, make([]byte, 62)...), 1},
+	}
+	for _, tt := range tests {
+		if got := BytesToInt(tt.genes); got != tt.want {
+			t.Fatalf("%d бит: %d, ожидалось %d", len(tt.genes), got, tt.want)
+		}
+	}
+
+	// Края отрезка при длине 62 и 63.
+	for _, bits := range []int{62, 63} {
+		if got := BytesToFloat(allOnes(bits), -1, 1); got != 1 {
+			t.Fatalf("%d единиц: BytesToFloat = %v, ожидалось 1", bits, got)
+		}
+		if got := BytesToFloat(make([]byte, bits), -1, 1); got != -1 {
+			t.Fatalf("%d нулей: BytesToFloat = %v, ожидалось -1", bits, got)
+		}
+		if got := BytesToInt(FloatToBytes(1, -1, 1, bits)); got != int(maxDecodedValue(bits)) {
+			t.Fatalf("%d бит: FloatToBytes(max) декодируется в %d", bits, got)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("нет паники для хромосомы длиннее MaxDecodeBits")
+		}
+	}()
+	BytesToInt(make([]byte, MaxDecodeBits+1))
+}
+
+// Срезы ConvergenceHistory имеют длину, равную числу выполненных поколений,
+// — и при полном запуске, и при ранней остановке, и в двухфазном режиме, —
+// а средняя и отклонение совпадают со сводками OnGeneration.
+func TestConvergenceHistoryLengths(t *testing.T) {
+	stagnating := validConfig()
+	stagnating.MaxGenerations = 50
+	stagnating.StagnationLimit = 5
+
+	full := validConfig()
+	full.MaxGenerations = 12
+	full.FitnessFunc = func(genes []byte) float64 { return float64(BytesToInt(genes)) }
+
+	configs := map[string]Config{"полный": full, "застой": stagnating, "двухфазный": twoPhaseConfig()}
+	for name, config := range configs {
+		var stats []GenerationStats
+		config.OnGeneration = func(_ int, s GenerationStats) { stats = append(stats, s) }
+		algorithm := NewGeneticAlgorithm(config)
+		_, history := algorithm.Run()
+
+		h := algorithm.ConvergenceHistory()
+		generations := len(stats)
+		if len(history) != generations || len(h.Best) != generations ||
+			len(h.Mean) != generations || len(h.StdDev) != generations {
+			t.Fatalf("%s: поколений %d, длины истории %d, Best %d, Mean %d, StdDev %d",
+				name, generations, len(history), len(h.Best), len(h.Mean), len(h.StdDev))
+		}
+		for gen, s := range stats {
+			if h.Best[gen] != s.Best || h.Mean[gen] != s.Mean || h.StdDev[gen] != s.StdDev {
+				t.Fatalf("%s, поколение %d: история (%v, %v, %v), OnGeneration (%v, %v, %v)",
+					name, gen, h.Best[gen], h.Mean[gen], h.StdDev[gen], s.Best, s.Mean, s.StdDev)
+			}
+		}
+	}
+}
+
+// Доля элиты переводится в round(доля·размер популяции), заменяя
+// ElitismCount, и никогда не доходит до размера популяции.
+func TestEliteCountFromFraction(t *testing.T) {
+	tests := []struct {
+		fraction float64
+		pop      int
+		want     int
+	}{
+		{0, 50, 3}, // доля не задана — ElitismCount
+		{0.05, 50, 3},
+		{0.05, 100, 5},
+		{0.05, 200, 10},
+		{0.1, 15, 2}, // 1.5 округляется вверх
+		{0.01, 20, 0},
+		{0.5, 3, 2},
+		{0.99, 10, 9},
+		{0.99, 100, 99},
+		{0.9, 1, 0},
+	}
+	for _, tt := range tests {
+		config := Config{PopulationSize: tt.pop, ElitismCount: 3, ElitismFraction: tt.fraction}
+		if got := EliteCount(config); got != tt.want {
+			t.Errorf("доля %v при популяции %d: элита %d, ожидалось %d", tt.fraction, tt.pop, got, tt.want)
+		}
+	}
+
+	for pop := 1; pop <= 200; pop++ {
+		for fraction := 0.001; fraction < 1; fraction += 0.001 {
+			config := Config{PopulationSize: pop, ElitismFraction: fraction}
+			if got := EliteCount(config); got < 0 || got >= pop {
+				t.Fatalf("доля %v при популяции %d: элита %d вне [0, %d)", fraction, pop, got, pop)
+			}
+		}
+	}
+
+	// Даже при элите из всех особей, кроме одной, каждое поколение
+	// появляется новый потомок.
+	config := validConfig()
+	config.ElitismFraction = 0.99
+	config.MaxGenerations = 20
+	algorithm, err := NewGeneticAlgorithmChecked(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	algorithm.Run()
+	if want := config.PopulationSize + config.MaxGenerations - 1; algorithm.Stats().FitnessEvaluations < want {
+		t.Fatalf("вычислений приспособленности %d, ожидалось не меньше %d", algorithm.Stats().FitnessEvaluations, want)
+	}
+}
+
+// Медленная функция приспособленности и короткий MaxDuration: запуск
+// останавливается задолго до MaxGenerations с причиной TerminationTimeBudget
+// и без ошибки, возвращая лучшую особь выполненных поколений.
+func TestMaxDurationStopsSlowRun(t *testing.T) {
+	config := validConfig()
+	config.MaxGenerations = 1000
+	config.MaxDuration = 30 * time.Millisecond
+	config.FitnessFunc = func(genes []byte) float64 {
+		time.Sleep(200 * time.Microsecond)
+		return float64(BytesToInt(genes))
+	}
+
+	algorithm := NewGeneticAlgorithm(config)
+	start := time.Now()
+	best, history := algorithm.Run()
+	elapsed := time.Since(start)
+
+	if algorithm.TerminationReason() != TerminationTimeBudget || algorithm.Err() != nil {
+		t.Fatalf("причина остановки %q, ошибка %v; ожидалось %q без ошибки",
+			algorithm.TerminationReason(), algorithm.Err(), TerminationTimeBudget)
+	}
+	if len(history) == 0 || len(history) >= config.MaxGenerations {
+		t.Fatalf("выполнено %d поколений из %d", len(history), config.MaxGenerations)
+	}
+	if elapsed > time.Second {
+		t.Fatalf("запуск занял %v при MaxDuration %v", elapsed, config.MaxDuration)
+	}
+	if want := slices.Max(history); best.Fitness != want || config.FitnessFunc(best.Genes) != want {
+		t.Fatalf("возвращена особь с приспособленностью %v, лучшая в истории %v", best.Fitness, want)
+	}
+}
@@ -0,0 +1,65 @@
+package ga
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Улучшение глобального лучшего решения. Delta — величина улучшения
+// (положительна и при минимизации). Первое событие — начальный лучший
+// результат, его Delta равна нулю.
+type ImprovementEvent struct {
+	Generation int           `json:"generation"`
+	Fitness    float64       `json:"fitness"`
+	Delta      float64       `json:"delta"`
+	Elapsed    time.Duration `json:"elapsed_ns"`
+}
+
+type improvementLog struct {
+	enabled bool
+	start   time.Time
+	events  []ImprovementEvent
+}
+
+func (ga *GeneticAlgorithm) resetImprovements() {
+	ga.improvements = improvementLog{
+		enabled: ga.config.LogImprovements || ga.config.ImprovementWriter != nil,
+		start:   time.Now(),
+	}
+}
+
+func (ga *GeneticAlgorithm) recordImprovement(generation int, fitness float64) {
+	log := &ga.improvements
+	if !log.enabled {
+		return
+	}
+
+	delta := 0.0
+	if len(log.events) > 0 {
+		previous := log.events[len(log.events)-1].Fitness
+		if !ga.better(fitness, previous) {
+			return
+		}
+		delta = math.Abs(fitness - previous)
+	}
+
+	event := ImprovementEvent{
+		Generation: generation,
+		Fitness:    fitness,
+		Delta:      delta,
+		Elapsed:    time.Since(log.start),
+	}
+	log.events = append(log.events, event)
+
+	if ga.config.ImprovementWriter != nil {
+		fmt.Fprintf(ga.config.ImprovementWriter, "Улучшение: поколение %d, приспособленность=%.6f (+%.6f), прошло %v\n",
+			event.Generation, event.Fitness, event.Delta, event.Elapsed)
+	}
+}
+
+// Журнал улучшений последнего запуска (пустой, если LogImprovements
+// и ImprovementWriter не заданы).
+func (ga *GeneticAlgorithm) Improvements() []ImprovementEvent {
+	return ga.improvements.events
+}
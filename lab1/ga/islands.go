@@ -0,0 +1,135 @@
+package ga
+
+import "sync"
+
+// Островная модель: numIslands подпопуляций по cfg.PopulationSize особей
+// эволюционируют параллельно, и каждые migrationInterval поколений каждый
+// остров отправляет копии своих migrants лучших особей следующему по кольцу
+// (i → i+1), где они заменяют худших. Зерно острова i выводится из
+// cfg.Seed, поэтому при фиксированном cfg.Seed результат воспроизводим
+// независимо от планирования горутин.
+//
+// Возвращает лучшую особь всех островов и историю лучшей по всем островам
+// приспособленности. cfg.Rand, TwoPhase, OnGeneration и писатели отчётов
+// на островах не используются.
+func RunIslands(cfg Config, numIslands int, migrationInterval int, migrants int) (Individual, []float64) {
+	if numIslands < 1 {
+		numIslands = 1
+	}
+	migrants = max(0, min(migrants, cfg.PopulationSize))
+
+	// Буфер вмещает все отправки острова, поэтому отправка не блокирует,
+	// а остров, остановившийся раньше, закрывает канал.
+	capacity := 1
+	if migrationInterval > 0 {
+		capacity += cfg.MaxGenerations / migrationInterval
+	}
+	channels := make([]chan []Individual, numIslands)
+	for i := range channels {
+		channels[i] = make(chan []Individual, capacity)
+	}
+
+	islands := make([]*GeneticAlgorithm, numIslands)
+	bests := make([]Individual, numIslands)
+	histories := make([][]float64, numIslands)
+
+	var wg sync.WaitGroup
+	for i := range islands {
+		islandConfig := cfg
+		islandConfig.Seed = islandSeed(cfg.Seed, i)
+		islandConfig.Rand = nil
+		islandConfig.TwoPhase = false
+		islandConfig.OnGeneration = nil
+		islandConfig.ReportWriter = nil
+		islandConfig.ImprovementWriter = nil
+
+		island := NewGeneticAlgorithm(islandConfig)
+		islands[i] = island
+
+		out := channels[i]
+		in := channels[(i+numIslands-1)%numIslands]
+		if numIslands > 1 && migrationInterval > 0 && migrants > 0 {
+			island.observer = func(g Generation) bool {
+				if g.Index == 0 || g.Index%migrationInterval != 0 {
+					return true
+				}
+				out <- cloneIndividuals(g.population[:migrants])
+				if incoming, ok := <-in; ok {
+					island.acceptMigrants(g.population, incoming)
+				}
+				return true
+			}
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer close(out)
+			bests[i], histories[i] = island.Run()
+		}(i)
+	}
+	wg.Wait()
+
+	best := bests[0]
+	for _, b := range bests[1:] {
+		if islands[0].better(b.Fitness, best.Fitness) {
+			best = b
+		}
+	}
+	return best, mergeHistories(islands[0], histories)
+}
+
+// Мигранты заменяют худших особей; популяция пересортировывается, чтобы
+// элита следующего поколения учитывала и их.
+func (ga *GeneticAlgorithm) acceptMigrants(population, incoming []Individual) {
+	for k, migrant := range incoming {
+		population[len(population)-1-k] = migrant
+	}
+	ga.sortPopulation(population)
+}
+
+func cloneIndividuals(individuals []Individual) []Individual {
+	clones := make([]Individual, len(individuals))
+	for i, individual := range individuals {
+		clones[i] = cloneIndividual(individual)
+	}
+	return clones
+}
+
+// Копия особи, не разделяющая с ней гены.
+func cloneIndividual(individual Individual) Individual {
+	clone := individual
+	clone.Genes = append([]byte(nil), individual.Genes...)
+	if individual.RealGenes != nil {
+		clone.RealGenes = append([]float64(nil), individual.RealGenes...)
+	}
+	return clone
+}
+
+// Лучшее значение среди островов в каждом поколении; острова,
+// остановившиеся раньше, в поздних поколениях не учитываются.
+func mergeHistories(ga *GeneticAlgorithm, histories [][]float64) []float64 {
+	length := 0
+	for _, history := range histories {
+		length = max(length, len(history))
+	}
+
+	merged := make([]float64, length)
+	for gen := range merged {
+		first := true
+		for _, history := range histories {
+			if gen >= len(history) {
+				continue
+			}
+			if first || ga.better(history[gen], merged[gen]) {
+				merged[gen] = history[gen]
+				first = false
+			}
+		}
+	}
+	return merged
+}
+
+func islandSeed(seed int64, island int) int64 {
+	return seed ^ int64(uint64(island+1)*0x9E3779B97F4A7C15)
+}
@@ -0,0 +1,74 @@
+package ga
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+)
+
+func islandConfig() Config {
+	config := validConfig()
+	config.PopulationSize = 16
+	config.MaxGenerations = 30
+	config.MutationProb = 0.02
+	config.BitsPerGene = 24
+	config.Seed = 31
+	config.FitnessFunc = func(genes []byte) float64 {
+		return float64(BytesToInt(genes))
+	}
+	return config
+}
+
+// Запуски с одним зерном совпадают независимо от планирования горутин.
+func TestRunIslandsIsReproducible(t *testing.T) {
+	config := islandConfig()
+	wantBest, wantHistory := RunIslands(config, 4, 5, 2)
+	for run := 0; run < 10; run++ {
+		best, history := RunIslands(config, 4, 5, 2)
+		if !bytes.Equal(best.Genes, wantBest.Genes) || !slices.Equal(history, wantHistory) {
+			t.Fatalf("запуск %d с тем же зерном дал другой результат", run)
+		}
+	}
+	if len(wantHistory) != config.MaxGenerations {
+		t.Fatalf("история из %d поколений, ожидалось %d", len(wantHistory), config.MaxGenerations)
+	}
+
+	config.Seed++
+	if _, history := RunIslands(config, 4, 5, 2); slices.Equal(history, wantHistory) {
+		t.Fatal("другое зерно дало ту же историю")
+	}
+}
+
+// Зёрна островов одинаковы при любой миграции, поэтому расхождение
+// с запуском без миграции возникает только из-за переселённых особей.
+func TestRunIslandsMigrationMovesIndividuals(t *testing.T) {
+	config := islandConfig()
+	_, isolated := RunIslands(config, 4, 5, 0)
+	_, migrating := RunIslands(config, 4, 5, 4)
+	if slices.Equal(isolated, migrating) {
+		t.Fatal("миграция не изменила ход эволюции")
+	}
+
+	// Один остров совпадает с обычным запуском с его зерном.
+	single := config
+	single.Seed = islandSeed(config.Seed, 0)
+	_, want := NewGeneticAlgorithm(single).Run()
+	if _, got := RunIslands(config, 1, 5, 4); !slices.Equal(got, want) {
+		t.Fatal("единственный остров разошёлся с обычным запуском")
+	}
+}
+
+// Мигранты заменяют худших особей и занимают место по приспособленности.
+func TestAcceptMigrantsReplacesWorst(t *testing.T) {
+	algorithm := rankedPopulation(validConfig(), 5) // приспособленность 4, 3, 2, 1, 0
+	migrants := []Individual{{Genes: []byte{9}, Fitness: 10}, {Genes: []byte{8}, Fitness: 2.5}}
+	algorithm.acceptMigrants(algorithm.population, migrants)
+
+	got := make([]float64, len(algorithm.population))
+	for i, individual := range algorithm.population {
+		got[i] = individual.Fitness
+	}
+	if want := []float64{10, 4, 3, 2.5, 2}; !slices.Equal(got, want) {
+		t.Fatalf("после миграции %v, ожидалось %v", got, want)
+	}
+}
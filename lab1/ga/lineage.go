@@ -0,0 +1,143 @@
+package ga
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+type LineageRecord struct {
+	ID         int     `json:"id"`
+	Parents    []int   `json:"parents,omitempty"`
+	Operator   string  `json:"operator"`
+	Generation int     `json:"generation"`
+	Fitness    float64 `json:"fitness"`
+}
+
+func (ga *GeneticAlgorithm) resetLineage() {
+	ga.lineage = nil
+	ga.nextID = 0
+	if ga.config.TrackLineage {
+		ga.lineage = make(map[int]LineageRecord)
+	}
+}
+
+func (ga *GeneticAlgorithm) track(individual *Individual, generation int, operator string, parents ...int) {
+	if !ga.config.TrackLineage {
+		return
+	}
+
+	ga.nextID++
+	individual.ID = ga.nextID
+
+	ga.lineage[individual.ID] = LineageRecord{
+		ID:         individual.ID,
+		Parents:    parents,
+		Operator:   operator,
+		Generation: generation,
+		Fitness:    individual.Fitness,
+	}
+}
+
+// Оставляет в журнале только особей live и их предков.
+func (ga *GeneticAlgorithm) pruneLineage(live []Individual) {
+	if ga.lineage == nil {
+		return
+	}
+
+	kept := make(map[int]LineageRecord)
+	stack := make([]int, 0, len(live))
+	for _, individual := range live {
+		stack = append(stack, individual.ID)
+	}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if _, ok := kept[id]; ok {
+			continue
+		}
+		record, ok := ga.lineage[id]
+		if !ok {
+			continue
+		}
+		kept[id] = record
+		stack = append(stack, record.Parents...)
+	}
+	ga.lineage = kept
+}
+
+func lineageOperator(operator string, mutated bool) string {
+	if mutated {
+		return operator + "+mutation"
+	}
+	return operator
+}
+
+// Предки особи в порядке возрастания ID, начиная с самой особи. Журнал
+// хранит только предков последней популяции (см. TrackLineage), поэтому
+// для особи, не оставившей потомков, возвращается ошибка.
+func (ga *GeneticAlgorithm) Ancestry(individual Individual) ([]LineageRecord, error) {
+	if ga.lineage == nil {
+		return nil, fmt.Errorf("отслеживание происхождения выключено (TrackLineage)")
+	}
+	if _, ok := ga.lineage[individual.ID]; !ok {
+		return nil, fmt.Errorf("нет записи о происхождении особи %d", individual.ID)
+	}
+
+	visited := make(map[int]bool)
+	queue := []int{individual.ID}
+	records := make([]LineageRecord, 0)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+
+		record, ok := ga.lineage[id]
+		if !ok {
+			continue
+		}
+		records = append(records, record)
+		queue = append(queue, record.Parents...)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].ID < records[j].ID
+	})
+
+	return records, nil
+}
+
+func (ga *GeneticAlgorithm) ExportLineage(best Individual, w io.Writer) error {
+	records, err := ga.Ancestry(best)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(records)
+}
+
+func (ga *GeneticAlgorithm) ExportLineageDOT(best Individual, w io.Writer) error {
+	records, err := ga.Ancestry(best)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintln(w, "digraph lineage {"); err != nil {
+		return err
+	}
+	for _, r := range records {
+		fmt.Fprintf(w, "  n%d [label=\"#%d gen=%d\\n%s\\nf=%.4f\"];\n",
+			r.ID, r.ID, r.Generation, r.Operator, r.Fitness)
+		for _, parent := range r.Parents {
+			fmt.Fprintf(w, "  n%d -> n%d;\n", parent, r.ID)
+		}
+	}
+	_, err = fmt.Fprintln(w, "}")
+	return err
+}
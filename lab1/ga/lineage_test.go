@@ -0,0 +1,165 @@
+package ga
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func lineageConfig() Config {
+	config := validConfig()
+	config.PopulationSize = 12
+	config.MaxGenerations = 8
+	config.CrossoverProb = 0.5
+	config.MutationProb = 0.1
+	config.TrackLineage = true
+	config.Seed = 4
+	config.FitnessFunc = func(genes []byte) float64 { return float64(BytesToInt(genes)) }
+	return config
+}
+
+// Копия без скрещивания происходит от того родителя, с которого снята.
+func TestCopiedChildrenRecordTheirOwnParent(t *testing.T) {
+	config := lineageConfig()
+	config.CrossoverProb = 0
+	algorithm := NewGeneticAlgorithm(config)
+	algorithm.Initialize()
+
+	parent1, parent2 := algorithm.population[0], algorithm.population[1]
+	children := algorithm.breed(0, parent1, parent2, 2)
+	for i, want := range []int{parent1.ID, parent2.ID} {
+		record := algorithm.lineage[children[i].ID]
+		if !reflect.DeepEqual(record.Parents, []int{want}) {
+			t.Fatalf("потомок %d: родители %v, ожидался [%d]", i, record.Parents, want)
+		}
+		if !strings.HasPrefix(record.Operator, "copy") {
+			t.Fatalf("потомок %d: оператор %q, ожидалась копия", i, record.Operator)
+		}
+	}
+}
+
+func TestAncestryIsConsistent(t *testing.T) {
+	algorithm := NewGeneticAlgorithm(lineageConfig())
+	best, _ := algorithm.Run()
+
+	records := checkAncestry(t, algorithm, best)
+
+	var exported bytes.Buffer
+	if err := algorithm.ExportLineage(best, &exported); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []LineageRecord
+	if err := json.Unmarshal(exported.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, records) {
+		t.Fatal("ExportLineage выгрузил не ту родословную, что вернул Ancestry")
+	}
+
+	var dot bytes.Buffer
+	if err := algorithm.ExportLineageDOT(best, &dot); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(dot.String(), "digraph lineage {") {
+		t.Fatalf("неожиданный DOT: %q", dot.String())
+	}
+}
+
+// Проверяет родословную best: сама особь и все родители на месте, число
+// родителей соответствует оператору, родители старше потомков.
+func checkAncestry(t *testing.T, algorithm *GeneticAlgorithm, best Individual) []LineageRecord {
+	t.Helper()
+	records, err := algorithm.Ancestry(best)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byID := make(map[int]LineageRecord, len(records))
+	for _, record := range records {
+		byID[record.ID] = record
+	}
+	if _, ok := byID[best.ID]; !ok {
+		t.Fatalf("в родословной нет самой особи %d", best.ID)
+	}
+
+	for _, record := range records {
+		switch {
+		case record.Operator == "init" || record.Operator == "restart":
+			if len(record.Parents) != 0 {
+				t.Fatalf("случайная особь %d: родители %v, ожидалось ни одного", record.ID, record.Parents)
+			}
+		case strings.HasPrefix(record.Operator, "copy"):
+			if len(record.Parents) != 1 {
+				t.Fatalf("копия %d: родители %v, ожидался один", record.ID, record.Parents)
+			}
+		default:
+			if len(record.Parents) != 2 {
+				t.Fatalf("потомок %d (%s): родители %v, ожидалось два", record.ID, record.Operator, record.Parents)
+			}
+		}
+		for _, parentID := range record.Parents {
+			parent, ok := byID[parentID]
+			if !ok {
+				t.Fatalf("родитель %d особи %d отсутствует в родословной", parentID, record.ID)
+			}
+			if parent.Generation >= record.Generation {
+				t.Fatalf("родитель %d (поколение %d) не старше потомка %d (поколение %d)",
+					parentID, parent.Generation, record.ID, record.Generation)
+			}
+		}
+	}
+	return records
+}
+
+// Журнал хранит только предков живой популяции и не растёт линейно
+// с числом поколений.
+func TestLineageIsPrunedToLiveAncestors(t *testing.T) {
+	config := lineageConfig()
+	config.MaxGenerations = 300
+	algorithm := NewGeneticAlgorithm(config)
+	best, _ := algorithm.Run()
+	checkAncestry(t, algorithm, best)
+
+	live := make(map[int]bool)
+	for _, individual := range algorithm.population {
+		records, err := algorithm.Ancestry(individual)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, record := range records {
+			live[record.ID] = true
+		}
+	}
+	if len(algorithm.lineage) != len(live) {
+		t.Fatalf("в журнале %d записей, а предков живой популяции %d", len(algorithm.lineage), len(live))
+	}
+	if total := config.PopulationSize * config.MaxGenerations; len(algorithm.lineage) >= total/4 {
+		t.Fatalf("в журнале %d записей из %d созданных особей", len(algorithm.lineage), total)
+	}
+}
+
+// Особи второй фазы записываются в родословную и ведут к особям первой.
+func TestTwoPhaseOffspringAreTracked(t *testing.T) {
+	config := twoPhaseConfig()
+	config.TrackLineage = true
+	algorithm := NewGeneticAlgorithm(config)
+	best, _ := algorithm.Run()
+	if err := algorithm.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	records := checkAncestry(t, algorithm, best)
+	operators := make(map[string]bool)
+	for _, record := range records {
+		operators[record.Operator] = true
+	}
+	for _, operator := range []string{"init", "blend+mutation"} {
+		if !operators[operator] {
+			t.Fatalf("в родословной лучшей особи нет оператора %q: %v", operator, operators)
+		}
+	}
+	if !operators["copy"] && !operators["copy+mutation"] {
+		t.Fatalf("родословная не проходит через начальную популяцию второй фазы: %v", operators)
+	}
+}
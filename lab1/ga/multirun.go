@@ -0,0 +1,132 @@
+package ga
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Запуск, завершившийся ошибкой (Err() != nil): в сводные показатели
+// и поштучные срезы MultiRunResult он не входит.
+type RunFailure struct {
+	Seed        int64
+	Termination string
+	Err         error
+}
+
+// Поштучные срезы (Fitnesses, Convergences, Stats, Seeds, Terminations)
+// и сводные показатели относятся только к успешным запускам; неудачные
+// собраны в Failures.
+type MultiRunResult struct {
+	BestFitness  float64
+	MeanFitness  float64
+	StdDev       float64
+	Fitnesses    []float64
+	Convergences [][]float64
+	Stats        []RunStats
+	Seeds        []int64
+	Terminations []string
+	Failures     []RunFailure
+	TotalTime    time.Duration
+
+	minimize bool
+}
+
+// Среднее число вычислений приспособленности на запуск.
+func (m *MultiRunResult) MeanEvaluations() float64 {
+	if len(m.Stats) == 0 {
+		return 0
+	}
+	total := 0
+	for _, stats := range m.Stats {
+		total += stats.FitnessEvaluations
+	}
+	return float64(total) / float64(len(m.Stats))
+}
+
+// Общее число запусков, включая неудачные.
+func (m *MultiRunResult) Runs() int {
+	return len(m.Fitnesses) + len(m.Failures)
+}
+
+// Ошибки неудачных запусков, объединённые через errors.Join; nil, если
+// все запуски успешны.
+func (m *MultiRunResult) Err() error {
+	errs := make([]error, len(m.Failures))
+	for i, failure := range m.Failures {
+		errs[i] = fmt.Errorf("зерно %d: %w", failure.Seed, failure.Err)
+	}
+	return errors.Join(errs...)
+}
+
+// Выполняет runs независимых запусков ГА. Зерно i-го запуска — seeds[i],
+// а если seeds короче, то config.Seed + i. Пользовательский config.Rand
+// используется всеми запусками последовательно.
+func RunMany(config Config, runs int, seeds []int64) MultiRunResult {
+	result := MultiRunResult{minimize: config.Minimize}
+
+	for run := 0; run < runs; run++ {
+		runConfig := config
+		if run < len(seeds) {
+			runConfig.Seed = seeds[run]
+		} else {
+			runConfig.Seed = config.Seed + int64(run)
+		}
+		algorithm := NewGeneticAlgorithm(runConfig)
+
+		start := time.Now()
+		best, convergence := algorithm.Run()
+		result.TotalTime += time.Since(start)
+
+		if err := algorithm.Err(); err != nil {
+			result.Failures = append(result.Failures, RunFailure{
+				Seed:        runConfig.Seed,
+				Termination: algorithm.TerminationReason(),
+				Err:         err,
+			})
+			continue
+		}
+
+		result.Fitnesses = append(result.Fitnesses, best.Fitness)
+		result.Convergences = append(result.Convergences, convergence)
+		result.Stats = append(result.Stats, algorithm.Stats())
+		result.Seeds = append(result.Seeds, runConfig.Seed)
+		result.Terminations = append(result.Terminations, algorithm.TerminationReason())
+	}
+
+	result.summarize()
+	return result
+}
+
+// Добавляет запуски other к m и пересчитывает сводные показатели.
+func (m *MultiRunResult) Merge(other MultiRunResult) {
+	m.Fitnesses = append(m.Fitnesses, other.Fitnesses...)
+	m.Convergences = append(m.Convergences, other.Convergences...)
+	m.Stats = append(m.Stats, other.Stats...)
+	m.Seeds = append(m.Seeds, other.Seeds...)
+	m.Terminations = append(m.Terminations, other.Terminations...)
+	m.Failures = append(m.Failures, other.Failures...)
+	m.TotalTime += other.TotalTime
+	m.summarize()
+}
+
+func (m *MultiRunResult) summarize() {
+	if len(m.Fitnesses) == 0 {
+		return
+	}
+
+	m.BestFitness = m.Fitnesses[0]
+	m.MeanFitness = 0
+	for _, f := range m.Fitnesses {
+		m.MeanFitness += f
+		better := f > m.BestFitness
+		if m.minimize {
+			better = f < m.BestFitness
+		}
+		if better {
+			m.BestFitness = f
+		}
+	}
+	m.MeanFitness /= float64(len(m.Fitnesses))
+	m.StdDev = StdDev(m.Fitnesses, m.MeanFitness)
+}
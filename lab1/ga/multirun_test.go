@@ -0,0 +1,76 @@
+package ga
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// Запуски, завершившиеся ошибкой, попадают в Failures и не входят
+// в поштучные срезы и сводные показатели.
+func TestRunManyExcludesFailedRuns(t *testing.T) {
+	config := validConfig()
+	config.PopulationSize = 4
+	config.MaxGenerations = 1
+	config.ElitismCount = 1
+	config.BitsPerGene = 3
+	config.FitnessFunc = func(genes []byte) float64 {
+		if v := BytesToInt(genes); v != 7 {
+			return float64(v)
+		}
+		return math.NaN()
+	}
+
+	seeds := make([]int64, 12)
+	for i := range seeds {
+		seeds[i] = int64(i + 1)
+	}
+	multi := RunMany(config, len(seeds), seeds)
+
+	if len(multi.Failures) == 0 || len(multi.Fitnesses) == 0 {
+		t.Fatalf("ожидались и успешные, и неудачные запуски: %d успешных, %d неудачных",
+			len(multi.Fitnesses), len(multi.Failures))
+	}
+	if multi.Runs() != len(seeds) {
+		t.Fatalf("Runs() = %d, ожидалось %d", multi.Runs(), len(seeds))
+	}
+	if multi.Err() == nil {
+		t.Fatal("Err() = nil при неудачных запусках")
+	}
+	for _, n := range []int{len(multi.Convergences), len(multi.Stats), len(multi.Seeds), len(multi.Terminations)} {
+		if n != len(multi.Fitnesses) {
+			t.Fatalf("длины поштучных срезов расходятся: %d против %d", n, len(multi.Fitnesses))
+		}
+	}
+	for _, failure := range multi.Failures {
+		if failure.Termination != TerminationInvalidFitness || failure.Err == nil {
+			t.Fatalf("неудачный запуск %+v", failure)
+		}
+		if slices.Contains(multi.Seeds, failure.Seed) {
+			t.Fatalf("зерно неудачного запуска %d попало в Seeds", failure.Seed)
+		}
+	}
+
+	mean := 0.0
+	for _, f := range multi.Fitnesses {
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			t.Fatalf("в Fitnesses попало %v", f)
+		}
+		mean += f
+	}
+	mean /= float64(len(multi.Fitnesses))
+	if multi.MeanFitness != mean || multi.BestFitness != slices.Max(multi.Fitnesses) {
+		t.Fatalf("сводка Best=%v Mean=%v не совпадает с успешными запусками (max %v, mean %v)",
+			multi.BestFitness, multi.MeanFitness, slices.Max(multi.Fitnesses), mean)
+	}
+}
+
+// Merge переносит и неудачные запуски.
+func TestMultiRunMergeKeepsFailures(t *testing.T) {
+	var multi MultiRunResult
+	multi.Merge(MultiRunResult{Fitnesses: []float64{1}, Seeds: []int64{1}})
+	multi.Merge(MultiRunResult{Failures: []RunFailure{{Seed: 2, Termination: TerminationStalled}}})
+	if multi.Runs() != 2 || len(multi.Failures) != 1 || multi.MeanFitness != 1 {
+		t.Fatalf("после Merge: %+v", multi)
+	}
+}
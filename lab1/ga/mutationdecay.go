@@ -0,0 +1,24 @@
+package ga
+
+import "math"
+
+// Доля, на которую по умолчанию уменьшается превышение вероятности
+// мутации над MutationFloor за поколение.
+const defaultMutationDecay = 0.05
+
+// Вероятность мутации в текущем поколении для базовой вероятности base.
+// При AdaptiveMutation превышение над MutationFloor убывает
+// экспоненциально: floor + (base-floor)·(1-MutationDecay)^generation.
+func (ga *GeneticAlgorithm) decayedMutationProb(base float64) float64 {
+	floor := ga.config.MutationFloor
+	if !ga.config.AdaptiveMutation || base <= floor {
+		return base
+	}
+
+	decay := ga.config.MutationDecay
+	if decay <= 0 {
+		decay = defaultMutationDecay
+	}
+	decay = math.Min(decay, 1)
+	return floor + (base-floor)*math.Pow(1-decay, float64(ga.generation))
+}
@@ -0,0 +1,75 @@
+package ga
+
+import (
+	"math"
+	"testing"
+)
+
+// При AdaptiveMutation действующая вероятность мутации, записанная в
+// Stats().MutationProb, начинается с MutationProb и строго убывает к
+// MutationFloor, не опускаясь ниже; без AdaptiveMutation она постоянна.
+func TestAdaptiveMutationRateDecreases(t *testing.T) {
+	config := validConfig()
+	config.MaxGenerations = 60
+	config.MutationProb = 0.2
+	config.AdaptiveMutation = true
+	config.MutationDecay = 0.1
+	config.MutationFloor = 0.01
+	config.Seed = 4
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	algorithm := NewGeneticAlgorithm(config)
+	algorithm.Run()
+	rates := algorithm.Stats().MutationProb
+	if len(rates) != config.MaxGenerations || rates[0] != config.MutationProb {
+		t.Fatalf("записано %d вероятностей, первая %v; ожидалось %d, начиная с %v",
+			len(rates), rates[0], config.MaxGenerations, config.MutationProb)
+	}
+	for gen := 1; gen < len(rates); gen++ {
+		if rates[gen] >= rates[gen-1] || rates[gen] <= config.MutationFloor {
+			t.Fatalf("поколение %d: вероятность %v после %v, нижняя граница %v",
+				gen, rates[gen], rates[gen-1], config.MutationFloor)
+		}
+	}
+	if last := rates[len(rates)-1]; last > config.MutationFloor+0.001 {
+		t.Fatalf("за %d поколений вероятность опустилась лишь до %v", len(rates), last)
+	}
+
+	config.AdaptiveMutation = false
+	algorithm = NewGeneticAlgorithm(config)
+	algorithm.Run()
+	for gen, rate := range algorithm.Stats().MutationProb {
+		if rate != config.MutationProb {
+			t.Fatalf("без AdaptiveMutation в поколении %d вероятность %v, ожидалось %v", gen, rate, config.MutationProb)
+		}
+	}
+}
+
+// mutate использует вероятность текущего поколения: доля инвертированных
+// битов следует за убывающей вероятностью.
+func TestMutateUsesDecayedRate(t *testing.T) {
+	config := validConfig()
+	config.MutationProb = 0.4
+	config.AdaptiveMutation = true
+	config.MutationDecay = 0.1
+	config.Seed = 9
+	algorithm := NewGeneticAlgorithm(config)
+
+	const loci = 20000
+	for _, generation := range []int{0, 5, 20} {
+		algorithm.generation = generation
+		individual := Individual{Genes: make([]byte, loci)}
+		algorithm.mutate(&individual)
+		flips := 0
+		for _, gene := range individual.Genes {
+			flips += int(gene)
+		}
+
+		want := config.MutationProb * math.Pow(1-config.MutationDecay, float64(generation))
+		if got := float64(flips) / loci; math.Abs(got-want) > 0.015 {
+			t.Fatalf("поколение %d: инвертировано %.4f битов, ожидалось около %.4f", generation, got, want)
+		}
+	}
+}
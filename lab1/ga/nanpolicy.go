@@ -0,0 +1,60 @@
+package ga
+
+import (
+	"fmt"
+	"math"
+)
+
+// Значения Config.NaNPolicy.
+const (
+	NaNPolicyError    = "error"
+	NaNPolicyPenalize = "penalize"
+)
+
+// Некорректная приспособленность: NaN или бесконечность. С NaN сравнения
+// при сортировке не определены, и ГА без этой проверки молча возвращал бы
+// произвольную особь.
+func invalidFitness(fitness float64) bool {
+	return math.IsNaN(fitness) || math.IsInf(fitness, 0)
+}
+
+// Гены особи в читаемом виде: биты подряд или вектор вещественных генов.
+func formatGenotype(individual Individual) string {
+	if len(individual.RealGenes) > 0 {
+		return fmt.Sprint(individual.RealGenes)
+	}
+	bits := make([]byte, len(individual.Genes))
+	for i, gene := range individual.Genes {
+		bits[i] = '0' + gene
+	}
+	return string(bits)
+}
+
+// Худшее возможное значение приспособленности с учётом Minimize.
+func (ga *GeneticAlgorithm) worstFitness() float64 {
+	if ga.config.Minimize {
+		return math.Inf(1)
+	}
+	return math.Inf(-1)
+}
+
+// Применяет NaNPolicy к только что оценённой особи. Некорректное значение
+// в любом случае заменяется худшим, чтобы порядок сортировки оставался
+// определённым; при политике "error" (по умолчанию) дополнительно
+// запоминается первая такая ошибка, и Run останавливается после текущего
+// поколения с TerminationInvalidFitness.
+func (ga *GeneticAlgorithm) checkFitness(individual *Individual) {
+	if !invalidFitness(individual.Fitness) {
+		return
+	}
+	ga.stats.InvalidFitness++
+	value := individual.Fitness
+	individual.Fitness = ga.worstFitness()
+
+	if ga.config.NaNPolicy == NaNPolicyPenalize || ga.err != nil {
+		return
+	}
+	ga.err = fmt.Errorf("поколение %d: некорректная приспособленность %v у генотипа %s",
+		ga.generation, value, formatGenotype(*individual))
+	ga.termination = TerminationInvalidFitness
+}
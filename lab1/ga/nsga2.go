@@ -0,0 +1,199 @@
+package ga
+
+import (
+	"math"
+	"sort"
+)
+
+// Многокритериальный режим в духе NSGA-II: популяция родителей и потомков
+// сортируется по фронтам Парето, следующее поколение заполняется фронтами
+// целиком, а последний помещающийся фронт — особями с наибольшим
+// расстоянием скученности. Родители выбираются бинарным турниром по рангу
+// фронта, при равенстве — по расстоянию скученности. Операторы скрещивания
+// и мутации — те же, что у Run; Fitness особей не используется.
+//
+// Возвращает первый (недоминируемый) фронт итоговой популяции.
+func (ga *GeneticAlgorithm) RunMultiObjective() []Individual {
+	ga.stats = RunStats{}
+	ga.generation = 0
+
+	size := ga.config.PopulationSize
+	population := make([]Individual, size)
+	for i := range population {
+		population[i] = Individual{Genes: ga.randomGenes()}
+		ga.evaluateObjectives(&population[i])
+	}
+	ranks, crowding := ga.rankPopulation(population)
+
+	for generation := 0; generation < ga.config.MaxGenerations; generation++ {
+		ga.generation = generation
+
+		offspring := make([]Individual, 0, size)
+		for len(offspring) < size {
+			p1 := ga.crowdedTournament(population, ranks, crowding)
+			p2 := ga.crowdedTournament(population, ranks, crowding)
+
+			var children []Individual
+			if ga.rng.Float64() < ga.config.CrossoverProb {
+				children = ga.crossover(ga.config.CrossoverType, p1, p2, min(2, size-len(offspring)))
+			} else {
+				children = []Individual{{Genes: append([]byte(nil), p1.Genes...)}}
+			}
+
+			for _, child := range children {
+				child.Genes = append([]byte(nil), child.Genes...)
+				ga.mutate(&child)
+				ga.evaluateObjectives(&child)
+				offspring = append(offspring, child)
+			}
+		}
+
+		combined := append(population, offspring...)
+		population = ga.selectSurvivors(combined, size)
+		ranks, crowding = ga.rankPopulation(population)
+	}
+
+	var front []Individual
+	for i, individual := range population {
+		if ranks[i] == 0 {
+			front = append(front, individual)
+		}
+	}
+	return front
+}
+
+func (ga *GeneticAlgorithm) evaluateObjectives(individual *Individual) {
+	individual.Objectives = ga.config.FitnessFuncMulti(individual.Genes)
+	ga.stats.FitnessEvaluations++
+}
+
+// a доминирует b: не хуже по всем целям и лучше хотя бы по одной.
+func (ga *GeneticAlgorithm) dominates(a, b Individual) bool {
+	strictly := false
+	for k := range a.Objectives {
+		if ga.better(b.Objectives[k], a.Objectives[k]) {
+			return false
+		}
+		if ga.better(a.Objectives[k], b.Objectives[k]) {
+			strictly = true
+		}
+	}
+	return strictly
+}
+
+// Быстрая недоминируемая сортировка: индексы особей по фронтам, начиная
+// с недоминируемого.
+func (ga *GeneticAlgorithm) paretoFronts(population []Individual) [][]int {
+	n := len(population)
+	dominatedBy := make([]int, n)
+	dominating := make([][]int, n)
+	var current []int
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i == j {
+				continue
+			}
+			if ga.dominates(population[i], population[j]) {
+				dominating[i] = append(dominating[i], j)
+			} else if ga.dominates(population[j], population[i]) {
+				dominatedBy[i]++
+			}
+		}
+		if dominatedBy[i] == 0 {
+			current = append(current, i)
+		}
+	}
+
+	var fronts [][]int
+	for len(current) > 0 {
+		fronts = append(fronts, current)
+		var next []int
+		for _, i := range current {
+			for _, j := range dominating[i] {
+				dominatedBy[j]--
+				if dominatedBy[j] == 0 {
+					next = append(next, j)
+				}
+			}
+		}
+		sort.Ints(next)
+		current = next
+	}
+	return fronts
+}
+
+// Расстояние скученности особей одного фронта: сумма по целям нормированных
+// расстояний между соседями; крайние особи получают +Inf.
+func crowdingDistance(population []Individual, front []int) map[int]float64 {
+	distance := make(map[int]float64, len(front))
+	if len(front) == 0 {
+		return distance
+	}
+
+	objectives := len(population[front[0]].Objectives)
+	order := append([]int(nil), front...)
+	for k := 0; k < objectives; k++ {
+		sort.SliceStable(order, func(a, b int) bool {
+			return population[order[a]].Objectives[k] < population[order[b]].Objectives[k]
+		})
+
+		lo := population[order[0]].Objectives[k]
+		hi := population[order[len(order)-1]].Objectives[k]
+		distance[order[0]] = math.Inf(1)
+		distance[order[len(order)-1]] = math.Inf(1)
+		if hi == lo {
+			continue
+		}
+		for i := 1; i < len(order)-1; i++ {
+			gap := population[order[i+1]].Objectives[k] - population[order[i-1]].Objectives[k]
+			distance[order[i]] += gap / (hi - lo)
+		}
+	}
+	return distance
+}
+
+// Ранг фронта и расстояние скученности каждой особи.
+func (ga *GeneticAlgorithm) rankPopulation(population []Individual) ([]int, []float64) {
+	ranks := make([]int, len(population))
+	crowding := make([]float64, len(population))
+	for rank, front := range ga.paretoFronts(population) {
+		distance := crowdingDistance(population, front)
+		for _, i := range front {
+			ranks[i] = rank
+			crowding[i] = distance[i]
+		}
+	}
+	return ranks, crowding
+}
+
+func (ga *GeneticAlgorithm) selectSurvivors(combined []Individual, size int) []Individual {
+	survivors := make([]Individual, 0, size)
+	for _, front := range ga.paretoFronts(combined) {
+		if len(survivors)+len(front) <= size {
+			for _, i := range front {
+				survivors = append(survivors, combined[i])
+			}
+			continue
+		}
+
+		distance := crowdingDistance(combined, front)
+		order := append([]int(nil), front...)
+		sort.SliceStable(order, func(a, b int) bool {
+			return distance[order[a]] > distance[order[b]]
+		})
+		for _, i := range order[:size-len(survivors)] {
+			survivors = append(survivors, combined[i])
+		}
+		break
+	}
+	return survivors
+}
+
+func (ga *GeneticAlgorithm) crowdedTournament(population []Individual, ranks []int, crowding []float64) Individual {
+	a := ga.rng.Intn(len(population))
+	b := ga.rng.Intn(len(population))
+	if ranks[b] < ranks[a] || (ranks[b] == ranks[a] && crowding[b] > crowding[a]) {
+		a = b
+	}
+	return population[a]
+}
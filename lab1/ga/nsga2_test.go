@@ -0,0 +1,81 @@
+package ga
+
+import (
+	"math"
+	"testing"
+)
+
+const (
+	zdt1Variables = 4
+	zdt1Bits      = 10
+)
+
+// Двухкритериальная задача в духе ZDT1 (обе цели минимизируются):
+// f1 = x1, f2 = g·(1 - sqrt(x1/g)), g = 1 + 9·mean(x2..xn), xi ∈ [0, 1].
+// Фронт Парето — g = 1, то есть f2 = 1 - sqrt(f1).
+func zdt1(genes []byte) []float64 {
+	x := make([]float64, zdt1Variables)
+	for i := range x {
+		x[i] = BytesToFloat(genes[i*zdt1Bits:(i+1)*zdt1Bits], 0, 1)
+	}
+	g := 1.0
+	for _, xi := range x[1:] {
+		g += 9 * xi / float64(zdt1Variables-1)
+	}
+	return []float64{x[0], g * (1 - math.Sqrt(x[0]/g))}
+}
+
+func TestRunMultiObjectiveReturnsNonDominatedFront(t *testing.T) {
+	config := validConfig()
+	config.PopulationSize = 40
+	config.MaxGenerations = 100
+	config.MutationProb = 1.0 / (zdt1Variables * zdt1Bits)
+	config.BitsPerGene = zdt1Variables * zdt1Bits
+	config.Minimize = true
+	config.Seed = 3
+	config.FitnessFuncMulti = zdt1
+
+	algorithm, err := NewGeneticAlgorithmChecked(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	front := algorithm.RunMultiObjective()
+	if len(front) < 2 {
+		t.Fatalf("во фронте %d особей, ожидалось несколько", len(front))
+	}
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for i, a := range front {
+		for j, b := range front {
+			if i != j && algorithm.dominates(a, b) {
+				t.Fatalf("особь %d %v доминирует особь %d %v", i, a.Objectives, j, b.Objectives)
+			}
+		}
+		f1, f2 := a.Objectives[0], a.Objectives[1]
+		if gap := f2 - (1 - math.Sqrt(f1)); gap < -1e-9 || gap > 0.1 {
+			t.Errorf("особь %v отстоит от фронта Парето на %.3f", a.Objectives, gap)
+		}
+		lo, hi = math.Min(lo, f1), math.Max(hi, f1)
+	}
+	if hi-lo < 0.5 {
+		t.Errorf("фронт покрывает f1 ∈ [%.3f, %.3f], ожидался разброс не меньше 0.5", lo, hi)
+	}
+}
+
+func TestDominatesRespectsDirection(t *testing.T) {
+	a := Individual{Objectives: []float64{1, 2}}
+	b := Individual{Objectives: []float64{1, 3}}
+	c := Individual{Objectives: []float64{0, 4}}
+
+	maximize := &GeneticAlgorithm{config: Config{}}
+	minimize := &GeneticAlgorithm{config: Config{Minimize: true}}
+	if !maximize.dominates(b, a) || maximize.dominates(a, b) {
+		t.Error("при максимизации (1, 3) должна доминировать (1, 2)")
+	}
+	if !minimize.dominates(a, b) || minimize.dominates(b, a) {
+		t.Error("при минимизации (1, 2) должна доминировать (1, 3)")
+	}
+	if minimize.dominates(a, c) || minimize.dominates(c, a) || minimize.dominates(a, a) {
+		t.Error("несравнимые и равные особи не должны доминировать друг друга")
+	}
+}
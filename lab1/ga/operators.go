@@ -0,0 +1,112 @@
+package ga
+
+import "sort"
+
+// Минимальная вероятность оператора при адаптации, чтобы ни один
+// оператор не исчезал из смеси окончательно.
+const minOperatorProb = 0.05
+
+const operatorLearningRate = 0.1
+
+type operatorMix struct {
+	names   []string
+	quality []float64
+}
+
+func newOperatorMix(mix map[string]float64) operatorMix {
+	names := make([]string, 0, len(mix))
+	for name, weight := range mix {
+		if weight > 0 {
+			names = append(names, name)
+		}
+	}
+	// Порядок ключей map случаен, сортировка сохраняет воспроизводимость.
+	sort.Strings(names)
+
+	total := 0.0
+	for _, name := range names {
+		total += mix[name]
+	}
+
+	quality := make([]float64, len(names))
+	for i, name := range names {
+		quality[i] = mix[name] / total
+	}
+
+	return operatorMix{names: names, quality: quality}
+}
+
+func (om operatorMix) probabilities(adaptive bool) []float64 {
+	probs := make([]float64, len(om.quality))
+	total := 0.0
+	for _, q := range om.quality {
+		total += q
+	}
+
+	floor := 0.0
+	if adaptive && minOperatorProb*float64(len(probs)) < 1 {
+		floor = minOperatorProb
+	}
+
+	for i, q := range om.quality {
+		share := 1.0 / float64(len(probs))
+		if total > 0 {
+			share = q / total
+		}
+		probs[i] = floor + (1-floor*float64(len(probs)))*share
+	}
+	return probs
+}
+
+func (ga *GeneticAlgorithm) chooseCrossoverType() string {
+	if len(ga.operators.names) == 0 {
+		return ga.config.CrossoverType
+	}
+
+	probs := ga.operators.probabilities(ga.config.AdaptiveOperators)
+	r := ga.rng.Float64()
+	for i, p := range probs {
+		if r < p {
+			return ga.operators.names[i]
+		}
+		r -= p
+	}
+	return ga.operators.names[len(ga.operators.names)-1]
+}
+
+// Награда оператора — 1, если лучший потомок превзошёл лучшего родителя.
+func (ga *GeneticAlgorithm) creditOperator(operator string, parent1, parent2 Individual, children ...Individual) {
+	if !ga.config.AdaptiveOperators {
+		return
+	}
+
+	for i, name := range ga.operators.names {
+		if name != operator {
+			continue
+		}
+
+		reward := 0.0
+		bestParent := parent1.Fitness
+		if ga.better(parent2.Fitness, bestParent) {
+			bestParent = parent2.Fitness
+		}
+		for _, child := range children {
+			if ga.better(child.Fitness, bestParent) {
+				reward = 1
+			}
+		}
+
+		ga.operators.quality[i] += operatorLearningRate * (reward - ga.operators.quality[i])
+		return
+	}
+}
+
+// Текущие вероятности выбора операторов из CrossoverMix.
+func (ga *GeneticAlgorithm) OperatorProbabilities() map[string]float64 {
+	result := make(map[string]float64, len(ga.operators.names))
+	probs := ga.operators.probabilities(ga.config.AdaptiveOperators)
+	for i, name := range ga.operators.names {
+		result[name] = probs[i]
+	}
+	return result
+}
@@ -0,0 +1,73 @@
+package ga
+
+import (
+	"fmt"
+	"math"
+)
+
+// Разумные значения параметров ГА по умолчанию для готовых постановок.
+func defaultProblemConfig() Config {
+	return Config{
+		PopulationSize: 100,
+		MaxGenerations: 50,
+		CrossoverProb:  0.8,
+		MutationProb:   0.05,
+		CrossoverType:  "uniform",
+		ElitismCount:   2,
+	}
+}
+
+// Максимизация fn на [min, max]: длина хромосомы подбирается так, чтобы
+// шаг сетки декодирования был не больше precision (но не более 30 битов).
+// Границы и fn заполняются и для двухфазного режима (TwoPhase выключен).
+// Ошибка — если fn не задана, отрезок пуст или бесконечен либо precision
+// не положительна.
+func NewRealFunctionProblem(fn func(float64) float64, min, max float64, precision float64) (Config, error) {
+	if fn == nil {
+		return Config{}, fmt.Errorf("ga: не задана оптимизируемая функция")
+	}
+	if math.IsNaN(min) || math.IsNaN(max) || math.IsInf(min, 0) || math.IsInf(max, 0) || min >= max {
+		return Config{}, fmt.Errorf("ga: ожидается конечный отрезок с min < max, получено [%v, %v]", min, max)
+	}
+	if !(precision > 0) || math.IsInf(precision, 1) {
+		return Config{}, fmt.Errorf("ga: точность должна быть положительным конечным числом, получено %v", precision)
+	}
+
+	bits := int(math.Ceil(math.Log2((max-min)/precision + 1)))
+	if bits < 1 {
+		bits = 1
+	}
+	if bits > 30 {
+		bits = 30
+	}
+
+	config := defaultProblemConfig()
+	config.BitsPerGene = bits
+	config.FitnessFunc = func(genes []byte) float64 {
+		return fn(BytesToFloat(genes, min, max))
+	}
+	config.DecodeMin, config.DecodeMax = min, max
+	config.RealFitnessFunc = fn
+	return config, nil
+}
+
+// Поиск максимума в массиве: хромосома кодирует индекс, длина — наименьшая,
+// покрывающая все индексы. Значение отображается в индекс масштабированием
+// (см. DecodeIndex). Пустой массив — ошибка.
+func NewArraySearchProblem(data []float64) (Config, error) {
+	if len(data) == 0 {
+		return Config{}, fmt.Errorf("ga: пустой массив")
+	}
+
+	bits := 1
+	for (1 << bits) < len(data) {
+		bits++
+	}
+
+	config := defaultProblemConfig()
+	config.BitsPerGene = bits
+	config.FitnessFunc = func(genes []byte) float64 {
+		return data[DecodeIndex(genes, len(data), "binary")]
+	}
+	return config, nil
+}
@@ -0,0 +1,73 @@
+package ga
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewRealFunctionProblemRejectsBadInput(t *testing.T) {
+	square := func(x float64) float64 { return -x * x }
+	tests := []struct {
+		name      string
+		fn        func(float64) float64
+		min, max  float64
+		precision float64
+	}{
+		{"nil fn", nil, -1, 1, 0.01},
+		{"empty interval", square, 1, 1, 0.01},
+		{"reversed interval", square, 1, -1, 0.01},
+		{"infinite bound", square, math.Inf(-1), 1, 0.01},
+		{"NaN bound", square, math.NaN(), 1, 0.01},
+		{"zero precision", square, -1, 1, 0},
+		{"negative precision", square, -1, 1, -0.01},
+		{"NaN precision", square, -1, 1, math.NaN()},
+		{"infinite precision", square, -1, 1, math.Inf(1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewRealFunctionProblem(tt.fn, tt.min, tt.max, tt.precision); err == nil {
+				t.Fatal("ожидалась ошибка")
+			}
+		})
+	}
+}
+
+func TestNewRealFunctionProblem(t *testing.T) {
+	config, err := NewRealFunctionProblem(func(x float64) float64 { return -x * x }, -1, 1, 0.001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.BitsPerGene != 11 {
+		t.Fatalf("BitsPerGene = %d, ожидалось 11 (2/0.001 + 1 точек)", config.BitsPerGene)
+	}
+	config.Seed = 1
+	algorithm, err := NewGeneticAlgorithmChecked(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if best, _ := algorithm.Run(); best.Fitness > 0 || best.Fitness < -0.01 {
+		t.Fatalf("найдено %v, ожидалось значение около 0", best.Fitness)
+	}
+	if err := algorithm.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewArraySearchProblem(t *testing.T) {
+	if _, err := NewArraySearchProblem(nil); err == nil {
+		t.Fatal("для пустого массива ожидалась ошибка")
+	}
+
+	data := []float64{3, 1, 4, 1, 5}
+	config, err := NewArraySearchProblem(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.BitsPerGene != 3 {
+		t.Fatalf("BitsPerGene = %d, ожидалось 3", config.BitsPerGene)
+	}
+	config.Seed = 1
+	if best, _ := NewGeneticAlgorithm(config).Run(); best.Fitness != 5 {
+		t.Fatalf("найдено %v, ожидался максимум 5", best.Fitness)
+	}
+}
@@ -0,0 +1,43 @@
+package ga
+
+import (
+	"math/rand"
+	randv2 "math/rand/v2"
+)
+
+// Источник случайных чисел ГА. Подходит *rand.Rand из math/rand,
+// а также любой пользовательский генератор с теми же методами.
+type Rand interface {
+	Float64() float64
+	Intn(n int) int
+	NormFloat64() float64
+}
+
+// source: "pcg" (по умолчанию) — PCG из math/rand/v2,
+// "classic" — прежний math/rand для воспроизведения старых результатов.
+func NewRand(source string, seed int64) Rand {
+	if source == "classic" {
+		return rand.New(rand.NewSource(seed))
+	}
+	return NewPCGRand(seed)
+}
+
+type pcgRand struct {
+	r *randv2.Rand
+}
+
+func NewPCGRand(seed int64) Rand {
+	return pcgRand{r: randv2.New(randv2.NewPCG(uint64(seed), 0x9e3779b97f4a7c15))}
+}
+
+func (p pcgRand) Float64() float64 {
+	return p.r.Float64()
+}
+
+func (p pcgRand) Intn(n int) int {
+	return p.r.IntN(n)
+}
+
+func (p pcgRand) NormFloat64() float64 {
+	return p.r.NormFloat64()
+}
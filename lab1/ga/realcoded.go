@@ -0,0 +1,100 @@
+package ga
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Вещественное кодирование (Encoding: "real"): особь хранит RealGenes,
+// по одному числу на измерение из RealBounds, и оценивается
+// RealVectorFitnessFunc. Скрещивание — арифметическое (смешивающее),
+// мутация — гауссова с шагом MutationSigma от ширины измерения.
+
+func (ga *GeneticAlgorithm) realEncoding() bool {
+	return ga.config.Encoding == "real"
+}
+
+// Границы измерений: RealBounds, иначе одно измерение [DecodeMin, DecodeMax],
+// иначе [0, 1].
+func (ga *GeneticAlgorithm) realBounds() [][2]float64 {
+	if len(ga.config.RealBounds) > 0 {
+		return ga.config.RealBounds
+	}
+	if ga.config.DecodeMax > ga.config.DecodeMin {
+		return [][2]float64{{ga.config.DecodeMin, ga.config.DecodeMax}}
+	}
+	return [][2]float64{{0, 1}}
+}
+
+func (ga *GeneticAlgorithm) randomRealGenes() []float64 {
+	bounds := ga.realBounds()
+	genes := make([]float64, len(bounds))
+	for i, b := range bounds {
+		genes[i] = b[0] + ga.rng.Float64()*(b[1]-b[0])
+	}
+	return genes
+}
+
+func (ga *GeneticAlgorithm) realFitness(genes []float64) float64 {
+	if ga.config.RealVectorFitnessFunc != nil {
+		return ga.config.RealVectorFitnessFunc(genes)
+	}
+	return ga.config.RealFitnessFunc(genes[0])
+}
+
+// Арифметическое скрещивание: потомки — выпуклые комбинации родителей
+// с общим случайным весом alpha, симметричные друг другу.
+func (ga *GeneticAlgorithm) blendCrossover(parent1, parent2 Individual) (Individual, Individual) {
+	n := len(parent1.RealGenes)
+	if len(parent2.RealGenes) < n {
+		n = len(parent2.RealGenes)
+	}
+
+	alpha := ga.rng.Float64()
+	child1 := make([]float64, n)
+	child2 := make([]float64, n)
+	for i := 0; i < n; i++ {
+		child1[i] = alpha*parent1.RealGenes[i] + (1-alpha)*parent2.RealGenes[i]
+		child2[i] = (1-alpha)*parent1.RealGenes[i] + alpha*parent2.RealGenes[i]
+	}
+	return Individual{RealGenes: child1}, Individual{RealGenes: child2}
+}
+
+// Каждое измерение с вероятностью MutationProb сдвигается на N(0, σ),
+// σ = MutationSigma·(max - min) (по умолчанию 0.1), и обрезается по
+// границам. Гены копируются, так как потомок может разделять их с родителем.
+func (ga *GeneticAlgorithm) mutateReal(individual *Individual) bool {
+	sigma := ga.config.MutationSigma
+	if sigma <= 0 {
+		sigma = 0.1
+	}
+
+	bounds := ga.realBounds()
+	mutationProb := ga.decayedMutationProb(ga.config.MutationProb)
+	genes := append([]float64(nil), individual.RealGenes...)
+	mutated := false
+	for i := range genes {
+		if i >= len(bounds) || ga.rng.Float64() >= mutationProb {
+			continue
+		}
+		width := bounds[i][1] - bounds[i][0]
+		genes[i] = math.Max(bounds[i][0], math.Min(bounds[i][1], genes[i]+ga.rng.NormFloat64()*sigma*width))
+		mutated = true
+	}
+
+	individual.RealGenes = genes
+	return mutated
+}
+
+// Ключ генотипа для сравнения особей: биты для двоичного кодирования,
+// двоичное представление чисел для вещественного.
+func genotypeKey(individual Individual) string {
+	if len(individual.RealGenes) == 0 {
+		return string(individual.Genes)
+	}
+	key := make([]byte, 8*len(individual.RealGenes))
+	for i, v := range individual.RealGenes {
+		binary.LittleEndian.PutUint64(key[8*i:], math.Float64bits(v))
+	}
+	return string(key)
+}
@@ -0,0 +1,86 @@
+package ga
+
+import (
+	"math"
+	"testing"
+)
+
+// sin(x) + sin(10x/3) на [2.7, 7.5]: максимум 0.888315 при x ≈ 6.2173,
+// минимум -1.899599 при x ≈ 5.1457 (несколько локальных экстремумов).
+func TestRealEncodingOptimizesSinFunction(t *testing.T) {
+	fn := func(x float64) float64 { return math.Sin(x) + math.Sin(10.0/3.0*x) }
+	tests := []struct {
+		minimize bool
+		x, value float64
+	}{
+		{false, 6.2173, 0.888315},
+		{true, 5.1457, -1.899599},
+	}
+	for _, tt := range tests {
+		config := validConfig()
+		config.Encoding = "real"
+		config.BitsPerGene, config.FitnessFunc = 0, nil
+		config.DecodeMin, config.DecodeMax = 2.7, 7.5
+		config.RealFitnessFunc = fn
+		config.PopulationSize = 30
+		config.MaxGenerations = 60
+		config.MutationProb = 0.2
+		config.MutationSigma = 0.05
+		config.Minimize = tt.minimize
+		config.Seed = 11
+
+		algorithm, err := NewGeneticAlgorithmChecked(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		best, _ := algorithm.Run()
+		if len(best.RealGenes) != 1 || best.Genes != nil {
+			t.Fatalf("minimize=%v: особь с RealGenes %v и Genes %v, ожидался один вещественный ген",
+				tt.minimize, best.RealGenes, best.Genes)
+		}
+		x := best.RealGenes[0]
+		if math.Abs(x-tt.x) > 5e-3 || math.Abs(best.Fitness-tt.value) > 1e-4 || best.Fitness != fn(x) {
+			t.Fatalf("minimize=%v: найдено f(%.6f) = %.6f, ожидалось f(%.4f) = %.6f",
+				tt.minimize, x, best.Fitness, tt.x, tt.value)
+		}
+	}
+}
+
+// Несколько измерений: каждая координата независимо сходится к максимуму,
+// гены остаются в своих границах.
+func TestRealEncodingVector(t *testing.T) {
+	fn := func(x float64) float64 { return math.Sin(x) + math.Sin(10.0/3.0*x) }
+	config := validConfig()
+	config.Encoding = "real"
+	config.BitsPerGene, config.FitnessFunc = 0, nil
+	config.RealBounds = [][2]float64{{2.7, 7.5}, {2.7, 7.5}, {2.7, 7.5}}
+	config.RealVectorFitnessFunc = func(x []float64) float64 {
+		sum := 0.0
+		for _, xi := range x {
+			sum += fn(xi)
+		}
+		return sum
+	}
+	config.PopulationSize = 40
+	config.MaxGenerations = 150
+	config.MutationProb = 0.2
+	config.MutationSigma = 0.05
+	config.Seed = 11
+
+	algorithm, err := NewGeneticAlgorithmChecked(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	best, _ := algorithm.Run()
+	if len(best.RealGenes) != 3 {
+		t.Fatalf("у лучшей особи %d вещественных генов, ожидалось 3", len(best.RealGenes))
+	}
+	for i, x := range best.RealGenes {
+		if x < 2.7 || x > 7.5 {
+			t.Fatalf("ген %d = %v вне [2.7, 7.5]", i, x)
+		}
+	}
+	if want := 3 * 0.888315; best.Fitness < want-1e-3 {
+		t.Fatalf("найдено %v в %v, ожидалось около %v", best.Fitness, best.RealGenes, want)
+	}
+}
@@ -0,0 +1,25 @@
+package ga
+
+import "math"
+
+// Заменяет долю RestartFraction худших особей отсортированной популяции
+// новыми случайными; элита не затрагивается.
+func (ga *GeneticAlgorithm) restart(generation int) {
+	n := len(ga.population)
+	count := int(math.Round(ga.config.RestartFraction * float64(n)))
+	count = min(count, n-EliteCount(ga.config))
+	if count <= 0 {
+		return
+	}
+
+	for i := n - count; i < n; i++ {
+		ga.population[i] = ga.randomIndividual(generation, "restart")
+	}
+	ga.sortPopulation(ga.population)
+	ga.restarts = append(ga.restarts, generation)
+}
+
+// Поколения, в которых последний Run перезапускал часть популяции.
+func (ga *GeneticAlgorithm) Restarts() []int {
+	return ga.restarts
+}
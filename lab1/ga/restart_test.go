@@ -0,0 +1,49 @@
+package ga
+
+import (
+	"slices"
+	"testing"
+)
+
+// На плоском ландшафте без мутации и скрещивания популяция вырождается
+// дрейфом, а застой наступает каждые StagnationLimit поколений: каждый
+// перезапуск записывается в Restarts и возвращает разнообразие, а запуск
+// не завершается досрочно.
+func TestRestartInjectsDiversityOnFlatLandscape(t *testing.T) {
+	config := validConfig()
+	config.PopulationSize = 40
+	config.MaxGenerations = 100
+	config.BitsPerGene = 32
+	config.CrossoverProb = 0
+	config.MutationProb = 0
+	config.StagnationLimit = 30
+	config.RestartFraction = 0.5
+	config.Seed = 6
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	algorithm := NewGeneticAlgorithm(config)
+	_, history := algorithm.Run()
+	if len(history) != config.MaxGenerations || algorithm.TerminationReason() != TerminationMaxGenerations {
+		t.Fatalf("запуск остановлен после %d поколений с причиной %q", len(history), algorithm.TerminationReason())
+	}
+	if want := []int{30, 60, 90}; !slices.Equal(algorithm.Restarts(), want) {
+		t.Fatalf("перезапуски в поколениях %v, ожидалось %v", algorithm.Restarts(), want)
+	}
+
+	diversity := algorithm.Stats().Diversity
+	for _, generation := range algorithm.Restarts() {
+		before, after := diversity[generation], diversity[generation+1]
+		if before > 0.1 || after < before+0.2 {
+			t.Fatalf("поколение %d: разнообразие %.3f до перезапуска и %.3f после", generation, before, after)
+		}
+	}
+
+	// Без RestartFraction тот же застой завершает запуск.
+	config.RestartFraction = 0
+	algorithm = NewGeneticAlgorithm(config)
+	if _, history = algorithm.Run(); len(algorithm.Restarts()) != 0 || len(history) != config.StagnationLimit+1 {
+		t.Fatalf("без перезапусков: %v перезапусков, %d поколений", algorithm.Restarts(), len(history))
+	}
+}
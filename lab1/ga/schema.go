@@ -0,0 +1,157 @@
+package ga
+
+import (
+	"fmt"
+	"math"
+)
+
+// Обманчивая ловушка порядка k: хромосома делится на блоки по k битов,
+// блок из одних единиц даёт k, иначе k-1-u, где u — число единиц. Градиент
+// внутри блока ведёт к нулям, а глобальный оптимум — все единицы.
+// Неполный хвостовой блок не учитывается.
+func TrapFitness(k int) func([]byte) float64 {
+	return func(genes []byte) float64 {
+		if k <= 0 {
+			return 0
+		}
+
+		total := 0.0
+		for start := 0; start+k <= len(genes); start += k {
+			ones := 0
+			for _, g := range genes[start : start+k] {
+				if g == 1 {
+					ones++
+				}
+			}
+			if ones == k {
+				total += float64(k)
+			} else {
+				total += float64(k - 1 - ones)
+			}
+		}
+		return total
+	}
+}
+
+// Доля особей популяции, удовлетворяющих схеме. Схема — строка из '0', '1'
+// и '#' (любое значение); символ i соответствует гену i. Особи другой
+// длины схеме не удовлетворяют.
+func SchemaFrequency(pop []Individual, schema string) float64 {
+	if len(pop) == 0 {
+		return 0
+	}
+
+	count := 0
+	for _, individual := range pop {
+		if matchesSchema(individual.Genes, schema) {
+			count++
+		}
+	}
+	return float64(count) / float64(len(pop))
+}
+
+func matchesSchema(genes []byte, schema string) bool {
+	if len(genes) != len(schema) {
+		return false
+	}
+	for i := 0; i < len(schema); i++ {
+		switch schema[i] {
+		case '0':
+			if genes[i] != 0 {
+				return false
+			}
+		case '1':
+			if genes[i] != 1 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Порядок o(H) и определяющая длина δ(H) схемы.
+func schemaOrder(schema string) (order, definingLength int) {
+	first, last := -1, -1
+	for i := 0; i < len(schema); i++ {
+		if schema[i] == '#' {
+			continue
+		}
+		order++
+		if first < 0 {
+			first = i
+		}
+		last = i
+	}
+	if first >= 0 {
+		definingLength = last - first
+	}
+	return order, definingLength
+}
+
+// Прогноз доли схемы в следующем поколении по теореме о схемах:
+// p·f(H)/f̄·(1 - pc·d - o(H)·pm), где d — вероятность разрушения
+// скрещиванием (δ(H)/(l-1) для одноточечного, 1 - 0.5^(o(H)-1) для
+// равномерного). Теорема предполагает пропорциональный отбор, поэтому
+// для турнирного отбора прогноз лишь ориентировочный.
+func (ga *GeneticAlgorithm) predictSchemaFrequency(pop []Individual, schema string) float64 {
+	frequency := SchemaFrequency(pop, schema)
+	populationMean := meanFitness(pop)
+	if frequency == 0 || populationMean == 0 {
+		return 0
+	}
+
+	schemaSum, schemaCount := 0.0, 0
+	for _, individual := range pop {
+		if matchesSchema(individual.Genes, schema) {
+			schemaSum += individual.Fitness
+			schemaCount++
+		}
+	}
+
+	order, definingLength := schemaOrder(schema)
+	disruption := 0.0
+	if ga.config.CrossoverType == "onepoint" {
+		if len(schema) > 1 {
+			disruption = float64(definingLength) / float64(len(schema)-1)
+		}
+	} else if order > 0 {
+		disruption = 1 - math.Pow(0.5, float64(order-1))
+	}
+
+	survival := 1 - ga.config.CrossoverProb*disruption - float64(order)*ga.config.MutationProb
+	predicted := frequency * (schemaSum / float64(schemaCount)) / populationMean * math.Max(0, survival)
+	return math.Min(1, predicted)
+}
+
+// Запускает ГА и возвращает наблюдаемую долю схемы в каждом поколении
+// и прогноз теоремы о схемах, построенный по предыдущему поколению
+// (первый прогноз равен начальной наблюдаемой доле).
+func TraceSchema(config Config, schema string) (observed, predicted []float64, err error) {
+	for i := 0; i < len(schema); i++ {
+		if schema[i] != '0' && schema[i] != '1' && schema[i] != '#' {
+			return nil, nil, fmt.Errorf("схема %q: недопустимый символ %q", schema, schema[i])
+		}
+	}
+	if len(schema) != config.BitsPerGene {
+		return nil, nil, fmt.Errorf("длина схемы %d не совпадает с BitsPerGene %d", len(schema), config.BitsPerGene)
+	}
+
+	algorithm, err := NewGeneticAlgorithmChecked(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	next := 0.0
+	algorithm.observer = func(g Generation) bool {
+		frequency := SchemaFrequency(g.population, schema)
+		if len(observed) == 0 {
+			next = frequency
+		}
+		observed = append(observed, frequency)
+		predicted = append(predicted, next)
+		next = algorithm.predictSchemaFrequency(g.population, schema)
+		return true
+	}
+	algorithm.Run()
+
+	return observed, predicted, nil
+}
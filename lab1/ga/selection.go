@@ -0,0 +1,93 @@
+package ga
+
+import "math"
+
+// Выбор родителя согласно SelectionType.
+func (ga *GeneticAlgorithm) selectParent() Individual {
+	switch ga.config.SelectionType {
+	case "roulette":
+		return ga.rouletteSelection()
+	case "rank":
+		return ga.rankSelection()
+	default:
+		return ga.tournamentSelection()
+	}
+}
+
+// Пропорциональный отбор: вероятность выбора пропорциональна
+// приспособленности, сдвинутой на минимум по популяции (так допускаются
+// отрицательные значения; худшая особь получает нулевой вес). При
+// минимизации оценка берётся с обратным знаком. Если все оценки равны,
+// выбор равновероятен. Особи с бесконечной оценкой (штраф NaNPolicy)
+// получают нулевой вес. Равные оценки дают равные доли, поэтому
+// TimePenaltyFactor здесь не учитывается.
+func (ga *GeneticAlgorithm) rouletteSelection() Individual {
+	scores := make([]float64, len(ga.population))
+	minScore, finite := 0.0, false
+	for i, individual := range ga.population {
+		scores[i] = individual.Fitness
+		if ga.config.Minimize {
+			scores[i] = -scores[i]
+		}
+		if math.IsInf(scores[i], 0) {
+			continue
+		}
+		if !finite || scores[i] < minScore {
+			minScore, finite = scores[i], true
+		}
+	}
+
+	total := 0.0
+	for i := range scores {
+		if math.IsInf(scores[i], 0) {
+			scores[i] = 0
+			continue
+		}
+		scores[i] -= minScore
+		total += scores[i]
+	}
+	if total <= 0 {
+		return ga.population[ga.rng.Intn(len(ga.population))]
+	}
+
+	target := ga.rng.Float64() * total
+	for i, weight := range scores {
+		target -= weight
+		if target < 0 {
+			return ga.population[i]
+		}
+	}
+	return ga.population[len(ga.population)-1]
+}
+
+// Линейный ранговый отбор. Популяция отсортирована от лучшей особи
+// к худшей (так её оставляет Run перед размножением), и особь с рангом
+// i = 0..n-1 выбирается с вероятностью
+//
+//	p(i) = (s - (2s - 2)·i/(n - 1)) / n,
+//
+// где s = RankPressure в [1, 2] (по умолчанию 1.5): лучшая получает s/n,
+// худшая (2 - s)/n. Вероятность зависит только от места, а не от величины
+// приспособленности, поэтому разброс значений на порядки не даёт
+// нескольким особям захватить весь отбор.
+func (ga *GeneticAlgorithm) rankSelection() Individual {
+	n := len(ga.population)
+	if n == 1 {
+		return ga.population[0]
+	}
+
+	pressure := ga.config.RankPressure
+	if pressure == 0 {
+		pressure = 1.5
+	}
+	pressure = math.Max(1, math.Min(2, pressure))
+
+	target := ga.rng.Float64()
+	for i := 0; i < n; i++ {
+		target -= (pressure - (2*pressure-2)*float64(i)/float64(n-1)) / float64(n)
+		if target < 0 {
+			return ga.population[i]
+		}
+	}
+	return ga.population[n-1]
+}
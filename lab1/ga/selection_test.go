@@ -0,0 +1,207 @@
+package ga
+
+import (
+	"math"
+	"testing"
+)
+
+// Время вычисления решает исход турнира только при равной приспособленности.
+func TestTournamentTimePenaltyAppliesOnlyToTies(t *testing.T) {
+	config := validConfig()
+	config.TimePenaltyFactor = 1
+	algorithm := NewGeneticAlgorithm(config)
+
+	slowBetter := Individual{Genes: []byte{0, 0}, Fitness: 10.001, EvalCost: 1000}
+	fastWorse := Individual{Genes: []byte{0, 1}, Fitness: 10, EvalCost: 0.001}
+	if !algorithm.tournamentWins(slowBetter, fastWorse) || algorithm.tournamentWins(fastWorse, slowBetter) {
+		t.Fatal("время перевесило разницу в приспособленности")
+	}
+
+	slow := Individual{Genes: []byte{0, 0}, Fitness: 10, EvalCost: 5}
+	fast := Individual{Genes: []byte{1, 1}, Fitness: 10, EvalCost: 1}
+	if !algorithm.tournamentWins(fast, slow) || algorithm.tournamentWins(slow, fast) {
+		t.Fatal("при равной приспособленности не победила более быстрая особь")
+	}
+
+	algorithm.config.Minimize = true
+	if !algorithm.tournamentWins(fastWorse, slowBetter) {
+		t.Fatal("при минимизации меньшая приспособленность должна побеждать")
+	}
+	if !algorithm.tournamentWins(fast, slow) {
+		t.Fatal("при минимизации равные особи должны различаться временем")
+	}
+
+	algorithm.config.TimePenaltyFactor = 0
+	if !algorithm.tournamentWins(slow, fast) {
+		t.Fatal("без TimePenaltyFactor равные особи должны различаться генотипом")
+	}
+}
+
+// Популяция из n особей с приспособленностью 0, 1, …, n−1, отсортированная
+// по убыванию, как перед отбором в Run.
+func rankedPopulation(config Config, n int) *GeneticAlgorithm {
+	config.PopulationSize = n
+	algorithm := NewGeneticAlgorithm(config)
+	algorithm.population = make([]Individual, n)
+	for i := range algorithm.population {
+		fitness := n - 1 - i
+		algorithm.population[i] = Individual{Genes: SignedIntToBytes(int64(fitness), 8), Fitness: float64(fitness)}
+	}
+	return algorithm
+}
+
+// Средняя приспособленность draws выбранных родителей.
+func meanSelectedFitness(algorithm *GeneticAlgorithm, draws int) float64 {
+	sum := 0.0
+	for i := 0; i < draws; i++ {
+		sum += algorithm.selectParent().Fitness
+	}
+	return sum / float64(draws)
+}
+
+// Больший турнир выбирает лучших чаще: средняя приспособленность
+// победителя растёт с размером турнира и совпадает с ожиданием максимума
+// k равновероятных выборок с возвращением. Нулевой размер — 3.
+func TestTournamentSizeIncreasesPressure(t *testing.T) {
+	const n, draws = 20, 20000
+	config := validConfig()
+	config.Seed = 17
+
+	previous := -1.0
+	for _, size := range []int{1, 2, 3, 5, 10} {
+		config.TournamentSize = size
+		got := meanSelectedFitness(rankedPopulation(config, n), draws)
+
+		want := 0.0
+		for v := 1; v < n; v++ {
+			want += 1 - math.Pow(float64(v)/n, float64(size))
+		}
+		if math.Abs(got-want) > 0.15 {
+			t.Fatalf("турнир %d: средняя приспособленность %.3f, ожидалось %.3f", size, got, want)
+		}
+		if got <= previous {
+			t.Fatalf("турнир %d: средняя приспособленность %.3f не выше, чем у меньшего (%.3f)", size, got, previous)
+		}
+		previous = got
+	}
+
+	config.TournamentSize = 0
+	defaultMean := meanSelectedFitness(rankedPopulation(config, n), draws)
+	config.TournamentSize = 3
+	if three := meanSelectedFitness(rankedPopulation(config, n), draws); defaultMean != three {
+		t.Fatalf("размер по умолчанию дал %.3f, турнир из 3 — %.3f", defaultMean, three)
+	}
+}
+
+// Частоты выбора каждой особи популяции за draws выборов.
+func selectionFrequencies(algorithm *GeneticAlgorithm, draws int) []float64 {
+	index := make(map[float64]int, len(algorithm.population))
+	for i, individual := range algorithm.population {
+		index[individual.Fitness] = i
+	}
+	frequencies := make([]float64, len(algorithm.population))
+	for i := 0; i < draws; i++ {
+		frequencies[index[algorithm.selectParent().Fitness]] += 1 / float64(draws)
+	}
+	return frequencies
+}
+
+// Рулетка выбирает особь с вероятностью, пропорциональной
+// приспособленности за вычетом минимальной; сдвиг всех значений на
+// константу (в том числе в отрицательную область) распределение не меняет,
+// а при равных значениях выбор равновероятен.
+func TestRouletteSelectionDistribution(t *testing.T) {
+	const draws = 40000
+	config := validConfig()
+	config.SelectionType = "roulette"
+	config.Seed = 19
+
+	want := []float64{0.4, 0.3, 0.2, 0.1, 0}
+	for _, shift := range []float64{0, -10, 1000} {
+		algorithm := rankedPopulation(config, len(want))
+		for i := range algorithm.population {
+			algorithm.population[i].Fitness += shift
+		}
+		got := selectionFrequencies(algorithm, draws)
+		for i := range want {
+			if math.Abs(got[i]-want[i]) > 0.015 {
+				t.Fatalf("сдвиг %v: частоты %.3f, ожидалось %v", shift, got, want)
+			}
+		}
+		if got[len(got)-1] != 0 {
+			t.Fatalf("сдвиг %v: худшая особь выбрана с частотой %.4f", shift, got[len(got)-1])
+		}
+	}
+
+	algorithm := rankedPopulation(config, 4)
+	for i := range algorithm.population {
+		algorithm.population[i].Fitness = 7
+		algorithm.population[i].Genes = SignedIntToBytes(int64(i), 8)
+	}
+	counts := make(map[string]int)
+	for i := 0; i < draws; i++ {
+		counts[string(algorithm.selectParent().Genes)]++
+	}
+	for genes, count := range counts {
+		if frequency := float64(count) / draws; math.Abs(frequency-0.25) > 0.015 {
+			t.Fatalf("при равной приспособленности особь %v выбрана с частотой %.3f", []byte(genes), frequency)
+		}
+	}
+	if len(counts) != 4 {
+		t.Fatalf("при равной приспособленности выбрано %d особей из 4", len(counts))
+	}
+}
+
+// Вероятность ранга i из n — (s − (2s − 2)·i/(n − 1))/n: при s = 2
+// линейно от 2/n до нуля, при s = 1 — равновероятно.
+func TestRankSelectionDistribution(t *testing.T) {
+	const draws = 40000
+	config := validConfig()
+	config.SelectionType = "rank"
+	config.Seed = 23
+
+	for _, tt := range []struct {
+		pressure float64
+		want     []float64
+	}{
+		{2, []float64{0.4, 0.3, 0.2, 0.1, 0}},
+		{1, []float64{0.2, 0.2, 0.2, 0.2, 0.2}},
+		{1.5, []float64{0.3, 0.25, 0.2, 0.15, 0.1}},
+	} {
+		config.RankPressure = tt.pressure
+		got := selectionFrequencies(rankedPopulation(config, len(tt.want)), draws)
+		for i := range tt.want {
+			if math.Abs(got[i]-tt.want[i]) > 0.015 {
+				t.Fatalf("давление %v: частоты %.3f, ожидалось %v", tt.pressure, got, tt.want)
+			}
+		}
+	}
+}
+
+// Когда приспособленность различается на порядки, рулетка почти всегда
+// выбирает лучшую особь и теряет разнообразие, а ранговый отбор его
+// сохраняет: после 10 поколений различных генотипов у него больше.
+func TestRankSelectionKeepsMoreDiversityThanRoulette(t *testing.T) {
+	config := validConfig()
+	config.PopulationSize = 40
+	config.MaxGenerations = 10
+	config.MutationProb = 0.01
+	config.BitsPerGene = 16
+	config.FitnessFunc = func(genes []byte) float64 {
+		return math.Exp(40 * BytesToFloat(genes, 0, 1))
+	}
+
+	distinct := make(map[string]int)
+	for _, selection := range []string{"roulette", "rank"} {
+		config.SelectionType = selection
+		for seed := int64(1); seed <= 10; seed++ {
+			config.Seed = seed
+			algorithm := NewGeneticAlgorithm(config)
+			algorithm.Run()
+			distinct[selection] += algorithm.Stats().FinalDistinct
+		}
+	}
+	if distinct["rank"] <= distinct["roulette"] {
+		t.Fatalf("различных генотипов за 10 запусков: ранговый %d, рулетка %d", distinct["rank"], distinct["roulette"])
+	}
+}
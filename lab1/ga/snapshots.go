@@ -0,0 +1,52 @@
+package ga
+
+// Кольцевой буфер последних популяций. Гены копируются, так как особи
+// следующих поколений могут разделять с ними срезы.
+type snapshotRing struct {
+	items [][]Individual
+	next  int
+	full  bool
+}
+
+func newSnapshotRing(size int) snapshotRing {
+	if size <= 0 {
+		return snapshotRing{}
+	}
+	return snapshotRing{items: make([][]Individual, size)}
+}
+
+func (r *snapshotRing) push(population []Individual) {
+	if len(r.items) == 0 {
+		return
+	}
+
+	snapshot := make([]Individual, len(population))
+	for i, individual := range population {
+		snapshot[i] = individual
+		snapshot[i].Genes = append([]byte(nil), individual.Genes...)
+		if individual.RealGenes != nil {
+			snapshot[i].RealGenes = append([]float64(nil), individual.RealGenes...)
+		}
+	}
+
+	r.items[r.next] = snapshot
+	r.next = (r.next + 1) % len(r.items)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+func (r *snapshotRing) ordered() [][]Individual {
+	if !r.full {
+		return append([][]Individual(nil), r.items[:r.next]...)
+	}
+	result := make([][]Individual, 0, len(r.items))
+	result = append(result, r.items[r.next:]...)
+	return append(result, r.items[:r.next]...)
+}
+
+// Популяции последних SnapshotGenerations поколений (отсортированные по
+// убыванию приспособленности), от старой к новой.
+func (ga *GeneticAlgorithm) RecentPopulations() [][]Individual {
+	return ga.snapshots.ordered()
+}
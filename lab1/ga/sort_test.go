@@ -0,0 +1,98 @@
+package ga
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// При равной приспособленности порядок определяется генами и не зависит
+// от порядка особей на входе.
+func TestSortPopulationBreaksTiesByGenes(t *testing.T) {
+	algorithm := NewGeneticAlgorithm(validConfig())
+	rng := NewRand("", 5)
+
+	population := make([]Individual, 64)
+	for i := range population {
+		genes := make([]byte, 6)
+		for j := range genes {
+			genes[j] = byte(rng.Intn(2))
+		}
+		// Всего три различных значения приспособленности.
+		population[i] = Individual{Genes: genes, Fitness: float64(i % 3)}
+	}
+
+	want := cloneIndividuals(population)
+	algorithm.sortPopulation(want)
+	for i := 1; i < len(want); i++ {
+		if want[i-1].Fitness == want[i].Fitness && bytes.Compare(want[i-1].Genes, want[i].Genes) > 0 {
+			t.Fatalf("позиции %d и %d: при равной приспособленности гены не по возрастанию", i-1, i)
+		}
+	}
+
+	for trial := 0; trial < 20; trial++ {
+		shuffled := cloneIndividuals(population)
+		for i := len(shuffled) - 1; i > 0; i-- {
+			j := rng.Intn(i + 1)
+			shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+		}
+		algorithm.sortPopulation(shuffled)
+		if !reflect.DeepEqual(shuffled, want) {
+			t.Fatalf("перестановка %d отсортирована в другом порядке", trial)
+		}
+	}
+}
+
+// Два запуска с одним зерном на функции с множеством равных значений
+// дают одну и ту же лучшую особь и историю.
+func TestRunIsReproducibleWithTies(t *testing.T) {
+	config := validConfig()
+	config.PopulationSize = 30
+	config.MaxGenerations = 20
+	config.BitsPerGene = 12
+	config.Seed = 9
+	config.FitnessFunc = func(genes []byte) float64 {
+		ones := 0
+		for _, gene := range genes {
+			ones += int(gene)
+		}
+		return float64(ones / 3)
+	}
+
+	best1, history1 := NewGeneticAlgorithm(config).Run()
+	best2, history2 := NewGeneticAlgorithm(config).Run()
+	if !bytes.Equal(best1.Genes, best2.Genes) || !reflect.DeepEqual(history1, history2) {
+		t.Fatalf("запуски с одним зерном различаются: %v и %v", best1.Genes, best2.Genes)
+	}
+}
+
+// У вещественных особей ничьи разрешаются по RealGenes: двоичные гены
+// лишь округляют их и могут совпадать или упорядочивать иначе.
+func TestTiesCompareRealGenes(t *testing.T) {
+	algorithm := NewGeneticAlgorithm(validConfig())
+	population := []Individual{
+		{Genes: []byte{0, 1}, RealGenes: []float64{0.5, 0.3}, Fitness: 1},
+		{Genes: []byte{0, 1}, RealGenes: []float64{0.5, 0.1}, Fitness: 1},
+		{Genes: []byte{0, 0}, RealGenes: []float64{0.7}, Fitness: 1},
+		{Genes: []byte{1, 1}, RealGenes: []float64{-1}, Fitness: 1},
+	}
+	want := [][]float64{{-1}, {0.5, 0.1}, {0.5, 0.3}, {0.7}}
+
+	for trial := 0; trial < len(population); trial++ {
+		shuffled := cloneIndividuals(population)
+		shuffled[0], shuffled[trial] = shuffled[trial], shuffled[0]
+		algorithm.sortPopulation(shuffled)
+		for i, individual := range shuffled {
+			if !reflect.DeepEqual(individual.RealGenes, want[i]) {
+				t.Fatalf("перестановка %d, позиция %d: %v, ожидалось %v", trial, i, individual.RealGenes, want[i])
+			}
+		}
+	}
+
+	if !algorithm.tournamentWins(population[3], population[2]) || algorithm.tournamentWins(population[2], population[3]) {
+		t.Fatal("в турнире побеждает особь с большими RealGenes")
+	}
+	if !algorithm.tournamentWins(population[1], population[0]) {
+		t.Fatal("при равных двоичных генах турнир не смотрит на RealGenes")
+	}
+}
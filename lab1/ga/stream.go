@@ -0,0 +1,99 @@
+package ga
+
+import "context"
+
+// Сводка одного поколения для RunStream. Best — копия лучшей особи,
+// Distinct во второй (вещественной) фазе двухфазного режима не считается
+// и равен 0.
+type Generation struct {
+	Index       int
+	Best        Individual
+	BestFitness float64
+	MeanFitness float64
+	StdDev      float64
+	Distinct    int
+
+	// Вся популяция поколения без копирования, для внутренних наблюдателей.
+	population []Individual
+}
+
+// Запускает Run в отдельной горутине и отправляет сводку каждого поколения
+// в канал. Канал закрывается по окончании запуска. Отправка блокирует ГА,
+// пока получатель не прочитает значение; отмена ctx останавливает запуск
+// с причиной TerminationCancelled. Пока канал не закрыт, другие методы ga
+// вызывать нельзя.
+func (ga *GeneticAlgorithm) RunStream(ctx context.Context) <-chan Generation {
+	out := make(chan Generation)
+
+	go func() {
+		defer close(out)
+		ga.observer = func(g Generation) bool {
+			select {
+			case out <- g:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		defer func() { ga.observer = nil }()
+
+		ga.RunWithContext(ctx)
+	}()
+
+	return out
+}
+
+// Сводка поколения для Config.OnGeneration. BestGenes — копия генов
+// лучшей особи (во второй фазе двухфазного режима — nil), Diversity —
+// PopulationDiversity поколения.
+type GenerationStats struct {
+	Best      float64
+	Mean      float64
+	StdDev    float64
+	BestGenes []byte
+	Diversity float64
+}
+
+// Сообщает Config.OnGeneration и наблюдателю о поколении; false — запуск
+// нужно остановить. population отсортирована от лучшей особи к худшей.
+func (ga *GeneticAlgorithm) notify(index int, population []Individual, distinct int) bool {
+	if ga.observer == nil && ga.config.OnGeneration == nil {
+		return true
+	}
+
+	best := population[0]
+	best.Genes = append([]byte(nil), best.Genes...)
+	if best.RealGenes != nil {
+		best.RealGenes = append([]float64(nil), best.RealGenes...)
+	}
+
+	mean := meanFitness(population)
+	fitnesses := make([]float64, len(population))
+	for i, individual := range population {
+		fitnesses[i] = individual.Fitness
+	}
+
+	if ga.config.OnGeneration != nil {
+		ga.config.OnGeneration(index, GenerationStats{
+			Best:      best.Fitness,
+			Mean:      mean,
+			StdDev:    StdDev(fitnesses, mean),
+			BestGenes: append([]byte(nil), best.Genes...),
+			Diversity: PopulationDiversity(population),
+		})
+	}
+
+	if ga.observer != nil && !ga.observer(Generation{
+		Index:       index,
+		Best:        best,
+		BestFitness: best.Fitness,
+		MeanFitness: mean,
+		StdDev:      StdDev(fitnesses, mean),
+		Distinct:    distinct,
+		population:  population,
+	}) {
+		ga.termination = TerminationCancelled
+		return false
+	}
+	return true
+}
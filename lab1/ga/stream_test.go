@@ -0,0 +1,101 @@
+package ga
+
+import (
+	"math"
+	"testing"
+)
+
+// С элитизмом лучшая особь не теряется, поэтому Best в OnGeneration не
+// убывает (при минимизации — не растёт) и совпадает с историей Run.
+func TestOnGenerationBestIsMonotoneWithElitism(t *testing.T) {
+	for _, minimize := range []bool{false, true} {
+		config := validConfig()
+		config.PopulationSize = 20
+		config.MaxGenerations = 40
+		config.BitsPerGene = 16
+		config.MutationProb = 0.1 // сильная мутация: без элиты лучшая особь терялась бы
+		config.Minimize = minimize
+		config.Seed = 5
+		config.FitnessFunc = func(genes []byte) float64 {
+			x := float64(BytesToInt(genes))
+			return math.Sin(x / 2000)
+		}
+
+		var stats []GenerationStats
+		config.OnGeneration = func(gen int, s GenerationStats) {
+			if gen != len(stats) {
+				t.Fatalf("поколение %d пришло после %d", gen, len(stats))
+			}
+			stats = append(stats, s)
+		}
+		_, history := NewGeneticAlgorithm(config).Run()
+
+		if len(stats) != len(history) {
+			t.Fatalf("minimize=%v: %d вызовов OnGeneration на %d поколений", minimize, len(stats), len(history))
+		}
+		for gen, s := range stats {
+			if s.Best != history[gen] {
+				t.Fatalf("minimize=%v, поколение %d: Best = %v, в истории %v", minimize, gen, s.Best, history[gen])
+			}
+			if got := config.FitnessFunc(s.BestGenes); got != s.Best {
+				t.Fatalf("minimize=%v, поколение %d: BestGenes дают %v, а Best = %v", minimize, gen, got, s.Best)
+			}
+			if gen == 0 {
+				continue
+			}
+			worse := s.Best < stats[gen-1].Best
+			if minimize {
+				worse = s.Best > stats[gen-1].Best
+			}
+			if worse {
+				t.Fatalf("minimize=%v: лучшая приспособленность ухудшилась в поколении %d: %v → %v",
+					minimize, gen, stats[gen-1].Best, s.Best)
+			}
+		}
+		if stats[0].Best == stats[len(stats)-1].Best {
+			t.Errorf("minimize=%v: лучшая приспособленность не изменилась за %d поколений", minimize, len(stats))
+		}
+	}
+}
+
+// Разнообразие случайной начальной популяции близко к 1, а при элитизме
+// и слабой мутации падает почти до 0; OnGeneration получает те же значения,
+// что записаны в Stats().Diversity.
+func TestDiversityDropsFromRandomPopulation(t *testing.T) {
+	complementary := []Individual{{Genes: []byte{0, 1, 0}}, {Genes: []byte{1, 0, 1}}}
+	balanced := []Individual{{Genes: []byte{0, 1}}, {Genes: []byte{0, 1}}, {Genes: []byte{1, 0}}, {Genes: []byte{1, 0}}}
+	identical := []Individual{{Genes: []byte{1, 0}}, {Genes: []byte{1, 0}}, {Genes: []byte{1, 0}}}
+	if PopulationDiversity(complementary) != 1 || PopulationDiversity(balanced) != 1 || PopulationDiversity(identical) != 0 {
+		t.Fatalf("разнообразие %v, %v, %v; ожидалось 1, 1, 0", PopulationDiversity(complementary),
+			PopulationDiversity(balanced), PopulationDiversity(identical))
+	}
+
+	config := validConfig()
+	config.PopulationSize = 40
+	config.MaxGenerations = 80
+	config.BitsPerGene = 24
+	config.MutationProb = 0.002
+	config.Seed = 8
+	config.FitnessFunc = func(genes []byte) float64 { return float64(BytesToInt(genes)) }
+
+	var reported []float64
+	config.OnGeneration = func(_ int, s GenerationStats) { reported = append(reported, s.Diversity) }
+	algorithm := NewGeneticAlgorithm(config)
+	algorithm.Run()
+
+	diversity := algorithm.Stats().Diversity
+	if len(reported) != len(diversity) {
+		t.Fatalf("OnGeneration получил %d значений, записано %d", len(reported), len(diversity))
+	}
+	for gen := range diversity {
+		if reported[gen] != diversity[gen] {
+			t.Fatalf("поколение %d: в OnGeneration %v, записано %v", gen, reported[gen], diversity[gen])
+		}
+	}
+	if first := diversity[0]; first < 0.9 || first > 1 {
+		t.Errorf("разнообразие начальной популяции %.3f, ожидалось около 1", first)
+	}
+	if last := diversity[len(diversity)-1]; last > 0.1 {
+		t.Errorf("разнообразие итоговой популяции %.3f, ожидалось около 0", last)
+	}
+}
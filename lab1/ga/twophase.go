@@ -0,0 +1,122 @@
+package ga
+
+import (
+	"context"
+	"math"
+)
+
+func (ga *GeneticAlgorithm) twoPhaseEnabled() bool {
+	return ga.config.TwoPhase && !ga.realEncoding() && ga.config.RealFitnessFunc != nil && ga.config.DecodeMax > ga.config.DecodeMin
+}
+
+func (ga *GeneticAlgorithm) phaseOneGenerations() int {
+	split := ga.config.PhaseSplit
+	if split <= 0 || split > 1 {
+		split = 0.5
+	}
+
+	generations := int(math.Round(split * float64(ga.config.MaxGenerations)))
+	if generations < 1 {
+		generations = 1
+	}
+	if generations > ga.config.MaxGenerations {
+		generations = ga.config.MaxGenerations
+	}
+	return generations
+}
+
+// Вторая фаза: вещественная популяция вокруг лучшего решения первой фазы,
+// арифметическое скрещивание и гауссова мутация с убывающим шагом.
+// Начальный шаг — несколько ячеек двоичной сетки первой фазы. Особи
+// оцениваются через evaluate (с NaNPolicy, FitnessSamples и кэшем), а их
+// Genes — двоичная запись RealGenes, так что возвращённая особь согласована.
+// В родословной начальная популяция — копии seed (с мутацией, кроме
+// первой), потомки — "blend+mutation" от двух родителей; поколения второй
+// фазы нумеруются после поколения seed.
+func (ga *GeneticAlgorithm) refineReal(ctx context.Context, seed Individual, generations int) Individual {
+	min, max := ga.config.DecodeMin, ga.config.DecodeMax
+	x0 := DecodeFloat(seed.Genes, min, max, ga.config.Encoding)
+
+	sigma := (max - min) / math.Ldexp(1, len(seed.Genes)) * 4
+	if len(seed.Genes) == 0 {
+		sigma = (max - min) / 10
+	}
+
+	clip := func(x float64) float64 {
+		return math.Max(min, math.Min(max, x))
+	}
+	newReal := func(x float64, generation int, operator string, parents ...int) Individual {
+		x = clip(x)
+		individual := Individual{
+			Genes:     EncodeFloat(x, min, max, len(seed.Genes), ga.config.Encoding),
+			RealGenes: []float64{x},
+		}
+		ga.evaluate(&individual)
+		ga.track(&individual, generation, operator, parents...)
+		return individual
+	}
+	// Первое поколение второй фазы в родословной: seed — последнее двоичное.
+	lineageStart := ga.phaseOneGenerations() + 1
+
+	// Значения первой фазы получены другой функцией (FitnessFunc на сетке).
+	if ga.cache.entries != nil {
+		ga.cache.entries = make(map[string]cachedFitness)
+	}
+	ga.generation = ga.phaseOneGenerations()
+
+	population := make([]Individual, ga.config.PopulationSize)
+	population[0] = newReal(x0, lineageStart, "copy", seed.ID)
+	for i := 1; i < len(population); i++ {
+		population[i] = newReal(x0+ga.rng.NormFloat64()*sigma, lineageStart, "copy+mutation", seed.ID)
+	}
+
+	byFitness := func() {
+		ga.sortPopulation(population)
+	}
+
+	for generation := 0; generation < generations; generation++ {
+		if err := ctx.Err(); err != nil {
+			ga.termination = TerminationCancelled
+			ga.err = err
+			break
+		}
+		if ga.err != nil {
+			break
+		}
+		ga.generation = ga.phaseOneGenerations() + generation
+
+		byFitness()
+		ga.recordGeneration(population)
+		ga.recordImprovement(ga.phaseOneGenerations()+generation, population[0].Fitness)
+		if !ga.notify(ga.phaseOneGenerations()+generation, population, 0) {
+			break
+		}
+		if ga.timeBudgetExceeded() {
+			break
+		}
+
+		next := make([]Individual, 0, len(population))
+		for i := 0; i < EliteCount(ga.config) && i < len(population); i++ {
+			next = append(next, population[i])
+		}
+
+		for len(next) < len(population) {
+			a := population[ga.rng.Intn(len(population))]
+			b := population[ga.rng.Intn(len(population))]
+			if ga.better(b.Fitness, a.Fitness) {
+				a, b = b, a
+			}
+
+			alpha := ga.rng.Float64()
+			x := alpha*a.RealGenes[0] + (1-alpha)*b.RealGenes[0]
+			next = append(next, newReal(x+ga.rng.NormFloat64()*sigma, lineageStart+generation+1, "blend+mutation", a.ID, b.ID))
+		}
+
+		population = next
+		ga.pruneLineage(population)
+		sigma *= 0.9
+	}
+
+	byFitness()
+	return population[0]
+}
@@ -0,0 +1,69 @@
+package ga
+
+import (
+	"math"
+	"testing"
+)
+
+func twoPhaseConfig() Config {
+	config := validConfig()
+	config.PopulationSize = 20
+	config.MaxGenerations = 20
+	config.BitsPerGene = 10
+	config.Seed = 3
+	config.TwoPhase = true
+	config.DecodeMin, config.DecodeMax = -2, 2
+	config.RealFitnessFunc = func(x float64) float64 { return -(x - 0.3) * (x - 0.3) }
+	config.FitnessFunc = func(genes []byte) float64 {
+		return config.RealFitnessFunc(BytesToFloat(genes, config.DecodeMin, config.DecodeMax))
+	}
+	return config
+}
+
+// Гены, вещественные гены и приспособленность результата второй фазы
+// описывают одну и ту же точку.
+func TestTwoPhaseResultIsConsistent(t *testing.T) {
+	config := twoPhaseConfig()
+	algorithm := NewGeneticAlgorithm(config)
+	best, _ := algorithm.Run()
+	if err := algorithm.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(best.RealGenes) != 1 {
+		t.Fatalf("у результата второй фазы %d вещественных генов", len(best.RealGenes))
+	}
+	x := best.RealGenes[0]
+	if best.Fitness != config.RealFitnessFunc(x) {
+		t.Fatalf("Fitness = %v, а f(%v) = %v", best.Fitness, x, config.RealFitnessFunc(x))
+	}
+	step := (config.DecodeMax - config.DecodeMin) / float64(maxDecodedValue(config.BitsPerGene))
+	if decoded := BytesToFloat(best.Genes, config.DecodeMin, config.DecodeMax); math.Abs(decoded-x) > step/2 {
+		t.Fatalf("Genes декодируются в %v, RealGenes = %v (шаг сетки %v)", decoded, x, step)
+	}
+}
+
+// NaN во второй фазе обрабатывается по NaNPolicy, как и в первой.
+func TestTwoPhaseAppliesNaNPolicy(t *testing.T) {
+	config := twoPhaseConfig()
+	config.RealFitnessFunc = func(float64) float64 { return math.NaN() }
+
+	algorithm := NewGeneticAlgorithm(config)
+	best, _ := algorithm.Run()
+	if algorithm.Err() == nil || algorithm.TerminationReason() != TerminationInvalidFitness {
+		t.Fatalf("ошибка %v, причина остановки %q", algorithm.Err(), algorithm.TerminationReason())
+	}
+	if math.IsNaN(best.Fitness) {
+		t.Fatal("NaN попал в результат")
+	}
+
+	config.NaNPolicy = NaNPolicyPenalize
+	algorithm = NewGeneticAlgorithm(config)
+	algorithm.Run()
+	if err := algorithm.Err(); err != nil {
+		t.Fatalf("при NaNPolicyPenalize получена ошибка %v", err)
+	}
+	if algorithm.Stats().InvalidFitness == 0 {
+		t.Fatal("некорректные значения второй фазы не учтены")
+	}
+}
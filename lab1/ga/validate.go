@@ -0,0 +1,129 @@
+package ga
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Проверяет Config и возвращает все найденные нарушения сразу
+// (объединённые errors.Join), nil — если параметры корректны.
+func (c Config) Validate() error {
+	var errs []error
+	add := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("ga: "+format, args...))
+	}
+
+	if c.PopulationSize < 1 {
+		add("PopulationSize должен быть не меньше 1, получено %d", c.PopulationSize)
+	}
+	if c.MaxGenerations < 0 {
+		add("MaxGenerations не может быть отрицательным, получено %d", c.MaxGenerations)
+	}
+	if !inUnitInterval(c.CrossoverProb) {
+		add("CrossoverProb должна быть в [0, 1], получено %v", c.CrossoverProb)
+	}
+	if !inUnitInterval(c.MutationProb) {
+		add("MutationProb должна быть в [0, 1], получено %v", c.MutationProb)
+	}
+	if c.MaxDuration < 0 {
+		add("MaxDuration не может быть отрицательной, получено %v", c.MaxDuration)
+	}
+	if c.UniformMixRatio < 0 || c.UniformMixRatio >= 1 {
+		add("UniformMixRatio должна быть в (0, 1), получено %v", c.UniformMixRatio)
+	}
+	if c.ElitismCount < 0 || c.ElitismCount > c.PopulationSize {
+		add("ElitismCount должен быть в [0, PopulationSize = %d], получено %d", c.PopulationSize, c.ElitismCount)
+	}
+	if c.ElitismFraction < 0 || c.ElitismFraction >= 1 {
+		add("ElitismFraction должна быть в [0, 1), получено %v", c.ElitismFraction)
+	}
+	if c.RestartFraction < 0 || c.RestartFraction > 1 {
+		add("RestartFraction должна быть в [0, 1], получено %v", c.RestartFraction)
+	}
+	if c.TournamentSize < 0 {
+		add("TournamentSize должен быть не меньше 1, получено %d", c.TournamentSize)
+	}
+	if c.RankPressure != 0 && !(c.RankPressure >= 1 && c.RankPressure <= 2) {
+		add("RankPressure должно быть в [1, 2], получено %v", c.RankPressure)
+	}
+	if c.PhaseSplit != 0 && !(c.PhaseSplit > 0 && c.PhaseSplit < 1) {
+		add("PhaseSplit должна быть в (0, 1), получено %v", c.PhaseSplit)
+	}
+	if c.StagnationLimit < 0 {
+		add("StagnationLimit не может быть отрицательным, получено %d", c.StagnationLimit)
+	}
+	if !(c.ImprovementEpsilon >= 0) {
+		add("ImprovementEpsilon не может быть отрицательной, получено %v", c.ImprovementEpsilon)
+	}
+	if !inUnitInterval(c.MutationDecay) || !inUnitInterval(c.MutationFloor) {
+		add("MutationDecay и MutationFloor должны быть в [0, 1], получено %v и %v", c.MutationDecay, c.MutationFloor)
+	}
+
+	if !slices.Contains([]string{"", "uniform", "onepoint", "twopoint", "arithmetic"}, c.CrossoverType) {
+		add("неизвестный CrossoverType %q", c.CrossoverType)
+	}
+	if !slices.Contains([]string{"", "tournament", "roulette", "rank"}, c.SelectionType) {
+		add("неизвестный SelectionType %q", c.SelectionType)
+	}
+	if !slices.Contains([]string{"", "bitflip", "boundarylocal", "swap"}, c.MutationType) {
+		add("неизвестный MutationType %q", c.MutationType)
+	}
+	if !slices.Contains([]string{"", NaNPolicyError, NaNPolicyPenalize}, c.NaNPolicy) {
+		add("неизвестная NaNPolicy %q", c.NaNPolicy)
+	}
+	if !slices.Contains([]string{"", "binary", "gray", "real"}, c.Encoding) {
+		add("неизвестная Encoding %q", c.Encoding)
+	}
+
+	if c.Encoding == "real" {
+		if c.RealVectorFitnessFunc == nil && c.RealFitnessFunc == nil {
+			add("для Encoding \"real\" нужна RealVectorFitnessFunc или RealFitnessFunc")
+		}
+		for i, b := range c.RealBounds {
+			if !(b[0] < b[1]) {
+				add("RealBounds[%d] = %v: нижняя граница должна быть меньше верхней", i, b)
+			}
+		}
+	} else {
+		if c.FitnessFunc == nil && c.FitnessFuncMulti == nil {
+			add("не задана ни FitnessFunc, ни FitnessFuncMulti")
+		}
+		if c.BitsPerGene < 1 && !(c.VariableLength && c.MaxGenes > 0) {
+			add("BitsPerGene должен быть не меньше 1, получено %d", c.BitsPerGene)
+		}
+		if c.BitsPerGene > MaxDecodeBits {
+			add("BitsPerGene должен быть не больше MaxDecodeBits = %d, получено %d", MaxDecodeBits, c.BitsPerGene)
+		}
+	}
+
+	if err := checkMutationRates(c); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func inUnitInterval(v float64) bool {
+	return v >= 0 && v <= 1
+}
+
+func checkMutationRates(config Config) error {
+	if config.MutationRates == nil {
+		return nil
+	}
+
+	want := config.BitsPerGene
+	if config.VariableLength {
+		_, want = (&GeneticAlgorithm{config: config}).geneLengthBounds()
+	}
+	if len(config.MutationRates) != want {
+		return fmt.Errorf("ga: длина MutationRates %d не совпадает с длиной хромосомы %d", len(config.MutationRates), want)
+	}
+	for i, rate := range config.MutationRates {
+		if rate < 0 || rate > 1 || math.IsNaN(rate) {
+			return fmt.Errorf("ga: MutationRates[%d] = %v вне [0, 1]", i, rate)
+		}
+	}
+	return nil
+}
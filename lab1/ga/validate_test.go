@@ -0,0 +1,122 @@
+package ga
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func validConfig() Config {
+	return Config{
+		PopulationSize: 10,
+		MaxGenerations: 5,
+		CrossoverProb:  0.8,
+		MutationProb:   0.05,
+		ElitismCount:   2,
+		BitsPerGene:    8,
+		FitnessFunc:    func([]byte) float64 { return 0 },
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		want   string // подстрока ошибки; пусто — ошибки нет
+	}{
+		{"корректный", func(c *Config) {}, ""},
+		{"корректный вещественный", func(c *Config) {
+			c.Encoding, c.BitsPerGene, c.FitnessFunc = "real", 0, nil
+			c.RealFitnessFunc = math.Sin
+		}, ""},
+		{"нулевая популяция", func(c *Config) { c.PopulationSize, c.ElitismCount = 0, 0 }, "PopulationSize"},
+		{"отрицательное число поколений", func(c *Config) { c.MaxGenerations = -1 }, "MaxGenerations"},
+		{"CrossoverProb больше 1", func(c *Config) { c.CrossoverProb = 1.5 }, "CrossoverProb"},
+		{"отрицательная MutationProb", func(c *Config) { c.MutationProb = -0.1 }, "MutationProb"},
+		{"отрицательная MaxDuration", func(c *Config) { c.MaxDuration = -time.Second }, "MaxDuration"},
+		{"UniformMixRatio равна 1", func(c *Config) { c.UniformMixRatio = 1 }, "UniformMixRatio"},
+		{"элита больше популяции", func(c *Config) { c.ElitismCount = 11 }, "ElitismCount"},
+		{"ElitismFraction равна 1", func(c *Config) { c.ElitismFraction = 1 }, "ElitismFraction"},
+		{"RestartFraction больше 1", func(c *Config) { c.RestartFraction = 2 }, "RestartFraction"},
+		{"отрицательный TournamentSize", func(c *Config) { c.TournamentSize = -1 }, "TournamentSize"},
+		{"MutationDecay больше 1", func(c *Config) { c.MutationDecay = 2 }, "MutationDecay"},
+		{"неизвестный CrossoverType", func(c *Config) { c.CrossoverType = "threepoint" }, "CrossoverType"},
+		{"неизвестный SelectionType", func(c *Config) { c.SelectionType = "lottery" }, "SelectionType"},
+		{"неизвестный MutationType", func(c *Config) { c.MutationType = "scramble" }, "MutationType"},
+		{"неизвестная NaNPolicy", func(c *Config) { c.NaNPolicy = "ignore" }, "NaNPolicy"},
+		{"неизвестная Encoding", func(c *Config) { c.Encoding = "decimal" }, "Encoding"},
+		{"вещественная без функции", func(c *Config) { c.Encoding = "real" }, "RealVectorFitnessFunc"},
+		{"вырожденные RealBounds", func(c *Config) {
+			c.Encoding, c.RealFitnessFunc = "real", math.Sin
+			c.RealBounds = [][2]float64{{1, 1}}
+		}, "RealBounds[0]"},
+		{"нет FitnessFunc", func(c *Config) { c.FitnessFunc = nil }, "FitnessFunc"},
+		{"нулевая длина хромосомы", func(c *Config) { c.BitsPerGene = 0 }, "BitsPerGene"},
+		{"BitsPerGene 63", func(c *Config) { c.BitsPerGene = 63 }, ""},
+		{"BitsPerGene больше 63", func(c *Config) { c.BitsPerGene = 64 }, "BitsPerGene"},
+		{"RankPressure по умолчанию", func(c *Config) { c.RankPressure = 0 }, ""},
+		{"RankPressure 1", func(c *Config) { c.RankPressure = 1 }, ""},
+		{"RankPressure 2", func(c *Config) { c.RankPressure = 2 }, ""},
+		{"RankPressure меньше 1", func(c *Config) { c.RankPressure = 0.5 }, "RankPressure"},
+		{"RankPressure больше 2", func(c *Config) { c.RankPressure = 2.5 }, "RankPressure"},
+		{"RankPressure NaN", func(c *Config) { c.RankPressure = math.NaN() }, "RankPressure"},
+		{"PhaseSplit по умолчанию", func(c *Config) { c.PhaseSplit = 0 }, ""},
+		{"PhaseSplit 0.3", func(c *Config) { c.PhaseSplit = 0.3 }, ""},
+		{"PhaseSplit 1", func(c *Config) { c.PhaseSplit = 1 }, "PhaseSplit"},
+		{"отрицательная PhaseSplit", func(c *Config) { c.PhaseSplit = -0.2 }, "PhaseSplit"},
+		{"StagnationLimit 0", func(c *Config) { c.StagnationLimit = 0 }, ""},
+		{"отрицательный StagnationLimit", func(c *Config) { c.StagnationLimit = -1 }, "StagnationLimit"},
+		{"ImprovementEpsilon 0", func(c *Config) { c.ImprovementEpsilon = 0 }, ""},
+		{"отрицательная ImprovementEpsilon", func(c *Config) { c.ImprovementEpsilon = -1e-9 }, "ImprovementEpsilon"},
+		{"ImprovementEpsilon NaN", func(c *Config) { c.ImprovementEpsilon = math.NaN() }, "ImprovementEpsilon"},
+		{"длина MutationRates", func(c *Config) { c.MutationRates = make([]float64, 3) }, "MutationRates"},
+		{"MutationRates вне [0, 1]", func(c *Config) {
+			c.MutationRates = make([]float64, 8)
+			c.MutationRates[4] = math.NaN()
+		}, "MutationRates[4]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := validConfig()
+			tt.modify(&config)
+			err := config.Validate()
+			switch {
+			case tt.want == "" && err != nil:
+				t.Fatalf("неожиданная ошибка: %v", err)
+			case tt.want != "" && err == nil:
+				t.Fatalf("ожидалась ошибка про %s", tt.want)
+			case tt.want != "" && !strings.Contains(err.Error(), tt.want):
+				t.Fatalf("ошибка %q не упоминает %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateReportsAllViolations(t *testing.T) {
+	config := validConfig()
+	config.PopulationSize = 0
+	config.CrossoverType = "threepoint"
+	err := config.Validate()
+	if err == nil || !strings.Contains(err.Error(), "PopulationSize") || !strings.Contains(err.Error(), "CrossoverType") {
+		t.Fatalf("ожидались обе ошибки, получено %v", err)
+	}
+}
+
+// NewGeneticAlgorithm не проверяет Config и не паникует; ошибку
+// возвращает только NewGeneticAlgorithmChecked.
+func TestConstructorsOnInvalidConfig(t *testing.T) {
+	config := validConfig()
+	config.TournamentSize = -1
+
+	if NewGeneticAlgorithm(config) == nil {
+		t.Fatal("NewGeneticAlgorithm вернул nil")
+	}
+	if _, err := NewGeneticAlgorithmChecked(config); err == nil {
+		t.Fatal("NewGeneticAlgorithmChecked принял некорректный Config")
+	}
+	if _, err := NewGeneticAlgorithmChecked(validConfig()); err != nil {
+		t.Fatalf("NewGeneticAlgorithmChecked отверг корректный Config: %v", err)
+	}
+}
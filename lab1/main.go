@@ -1,10 +1,16 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
 	"time"
 
+	"golang.org/x/sync/errgroup"
+
 	"lab1/experiment"
 	"lab1/utils"
 )
@@ -14,63 +20,178 @@ func main() {
 	fmt.Println("Начало экспериментов...")
 	fmt.Println()
 
-	startTime := time.Now()
+	options := experiment.RunnerOptions{
+		Grid: experiment.ParamGrid{
+			PopulationSizes: []int{50, 100, 200},
+			MaxGenerations:  []int{25, 50, 75},
+			CrossoverProbs:  []float64{0.6, 0.8},
+			MutationProbs:   []float64{0.01, 0.05, 0.1},
+			CrossoverTypes:  []string{"onepoint", "uniform"},
+			ElitismCounts:   []int{2, 5},
+		},
+		ResultsFile: "results.json",
+	}
 
-	paramGrid := experiment.ParamGrid{
-		PopulationSizes: []int{50, 100, 200},
-		MaxGenerations:  []int{25, 50, 75},
-		CrossoverProbs:  []float64{0.6, 0.8},
-		MutationProbs:   []float64{0.01, 0.05, 0.1},
-		CrossoverTypes:  []string{"onepoint", "uniform"},
-		ElitismCounts:   []int{2, 5},
+	// Описание эксперимента можно передать JSON-файлом после флагов;
+	// явно заданные флаги переопределяют его значения.
+	flags, configFile := parseFlags()
+	if configFile != "" {
+		loaded, err := experiment.LoadConfig(configFile)
+		if err != nil {
+			log.Fatalf("Ошибка в файле конфигурации: %v", err)
+		}
+		options = loaded
+		fmt.Printf("Конфигурация загружена из %s\n", configFile)
+	}
+	if err := flags.apply(&options); err != nil {
+		log.Fatalf("Ошибка в параметрах командной строки: %v", err)
+	}
+	if err := options.Validate(); err != nil {
+		log.Fatalf("Ошибка в параметрах эксперимента: %v", err)
+	}
+	jobs, err := selectPlots(plotJobs(), flags.plots)
+	if err != nil {
+		log.Fatalf("Ошибка в параметре -plots: %v", err)
 	}
 
-	runner := experiment.NewExperimentRunner(paramGrid)
+	runner, err := options.NewRunner()
+	if err != nil {
+		log.Fatalf("Ошибка при подготовке экспериментов: %v", err)
+	}
+	if options.AutoTuneWorkers {
+		if _, err := runner.AutoTuneWorkers(); err != nil {
+			log.Fatalf("Ошибка при подборе числа потоков: %v", err)
+		}
+	}
 	results, err := runner.RunAllExperiments()
 	if err != nil {
 		log.Fatalf("Ошибка при выполнении экспериментов: %v", err)
 	}
 
-	err = results.SaveToJSON("results.json")
+	reportStart := time.Now()
+
+	// При потоковой записи результаты ГА не хранились в памяти во время
+	// экспериментов; для итогового JSON и графиков они читаются из потока.
+	if options.StreamFile != "" {
+		results.GAResults, err = experiment.LoadStreamedResults(options.StreamFile)
+		if err != nil {
+			log.Fatalf("Ошибка при чтении потока результатов: %v", err)
+		}
+	}
+
+	err = results.SaveToJSON(options.ResultsFile)
 	if err != nil {
 		log.Fatalf("Ошибка при сохранении результатов: %v", err)
 	}
 
+	if options.CSVFile != "" {
+		if err := results.SaveToCSV(options.CSVFile); err != nil {
+			log.Fatalf("Ошибка при сохранении CSV: %v", err)
+		}
+	}
+
+	if options.SQLiteFile != "" {
+		if err := results.SaveToSQLite(options.SQLiteFile); err != nil {
+			log.Fatalf("Ошибка при сохранении в SQLite: %v", err)
+		}
+	}
+
 	fmt.Println()
-	fmt.Printf("Эксперименты завершены за %v\n", time.Since(startTime))
-	fmt.Println("Результаты сохранены в results.json")
+	fmt.Printf("Эксперименты завершены за %v\n", runner.ComputeDuration())
+	fmt.Printf("Результаты сохранены в %s\n", options.ResultsFile)
+	if options.CSVFile != "" {
+		fmt.Printf("Таблица результатов сохранена в %s\n", options.CSVFile)
+	}
+	if options.SQLiteFile != "" {
+		fmt.Printf("База результатов сохранена в %s\n", options.SQLiteFile)
+	}
 	fmt.Println()
 
+	if len(jobs) == 0 {
+		fmt.Println("=== Работа завершена успешно! ===")
+		return
+	}
+
 	fmt.Println("Генерация графиков...")
 
-	err = utils.GenerateTimeComparisonPlot("results.json", "time_comparison.png")
+	plotResults, err := utils.LoadResults(options.ResultsFile)
 	if err != nil {
-		log.Printf("Предупреждение: не удалось создать график времени: %v", err)
-	} else {
-		fmt.Println("time_comparison.png создан")
+		log.Fatalf("Ошибка при чтении результатов: %v", err)
 	}
 
-	err = utils.GenerateConvergencePlot("results.json", "convergence_array.png")
-	if err != nil {
-		log.Printf("Предупреждение: не удалось создать график сходимости: %v", err)
-	} else {
-		fmt.Println("convergence_array.png создан")
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// Каталог графиков, заданный явно, создаётся при необходимости.
+	plotOptions := utils.PlotOptions{CreateDirs: options.PlotDir != ""}
+	if err := generatePlots(ctx, plotResults, options.PlotDir, plotOptions, jobs); err != nil {
+		log.Fatalf("Генерация графиков прервана: %v", err)
 	}
 
-	err = utils.GenerateAccuracyVsTimePlot("results.json", "accuracy_vs_time.png")
-	if err != nil {
-		log.Printf("Предупреждение: не удалось создать график точности: %v", err)
-	} else {
-		fmt.Println("accuracy_vs_time.png создан")
+	fmt.Println()
+	fmt.Printf("Сохранение результатов и графики заняли %v\n", time.Since(reportStart))
+	fmt.Println()
+	fmt.Println("=== Работа завершена успешно! ===")
+}
+
+type plotJob struct {
+	file   string
+	desc   string
+	render func(*utils.AllResults, string, utils.PlotOptions) error
+}
+
+func plotJobs() []plotJob {
+	return []plotJob{
+		{"time_comparison.png", "график времени", utils.RenderTimeComparisonPlot},
+		{"convergence_array.png", "график сходимости", func(r *utils.AllResults, out string, opts utils.PlotOptions) error {
+			return utils.RenderConvergencePlot(r, out, utils.ConvergenceFilter{}, opts)
+		}},
+		{"convergence_function.png", "график сходимости для функции", func(r *utils.AllResults, out string, opts utils.PlotOptions) error {
+			return utils.RenderConvergencePlot(r, out, utils.ConvergenceFilter{TaskName: "function_optimization"}, opts)
+		}},
+		{"accuracy_vs_time.png", "график точности", utils.RenderAccuracyVsTimePlot},
+		{"efficiency_comparison.png", "график эффективности", utils.RenderEfficiencyComparisonPlot},
+		{"efficiency_per_evaluation.png", "график эффективности на вычисление", func(r *utils.AllResults, out string, opts utils.PlotOptions) error {
+			return utils.RenderEfficiencyComparisonPlotMetric(r, out, utils.EfficiencyPerEvaluation, opts)
+		}},
+		{"selection_response.png", "график ответа на отбор", utils.RenderSelectionResponsePlot},
+		{"entropy.png", "график энтропии", utils.RenderEntropyPlot},
+		{"fitness_boxplot.png", "диаграмма размаха приспособленности", func(r *utils.AllResults, out string, opts utils.PlotOptions) error {
+			return utils.RenderFitnessBoxPlot(r, out, utils.BoxPlotByCrossover, opts)
+		}},
+		{"heatmap_population_mutation.png", "тепловая карта параметров", func(r *utils.AllResults, out string, opts utils.PlotOptions) error {
+			return utils.RenderParameterHeatmap(r, out, "PopulationSize", "MutationProb", opts)
+		}},
 	}
+}
 
-	err = utils.GenerateEfficiencyComparisonPlot("results.json", "efficiency_comparison.png")
-	if err != nil {
-		log.Printf("Предупреждение: не удалось создать график эффективности: %v", err)
-	} else {
-		fmt.Println("efficiency_comparison.png создан")
+func generatePlots(ctx context.Context, results *utils.AllResults, dir string, opts utils.PlotOptions, jobs []plotJob) error {
+	g, gctx := errgroup.WithContext(ctx)
+	for _, job := range jobs {
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+			// Ошибка отдельного графика не прерывает остальные.
+			file := filepath.Join(dir, job.file)
+			if err := job.render(results, file, opts); err != nil {
+				log.Printf("Предупреждение: не удалось создать %s: %v", job.desc, err)
+				return nil
+			}
+			fmt.Printf("%s создан\n", file)
+			return nil
+		})
 	}
 
-	fmt.Println()
-	fmt.Println("=== Работа завершена успешно! ===")
+	done := make(chan error, 1)
+	go func() {
+		done <- g.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+	"strconv"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// Группировка повторов на диаграмме размаха.
+const (
+	BoxPlotByCrossover  = "crossover"
+	BoxPlotByPopulation = "population"
+)
+
+func GenerateFitnessBoxPlot(resultsFile, outputFile string) error {
+	results, err := LoadResults(resultsFile)
+	if err != nil {
+		return err
+	}
+	return RenderFitnessBoxPlot(results, outputFile, BoxPlotByCrossover, PlotOptions{})
+}
+
+// Диаграмма размаха итоговой приспособленности отдельных повторов
+// (RunFitnessValues) задачи оптимизации функции; все конфигурации с
+// одинаковым типом скрещивания или размером популяции объединяются в один
+// ящик.
+func RenderFitnessBoxPlot(results *AllResults, outputFile, groupBy string, opts PlotOptions) error {
+	if err := ensureOutputDir(outputFile, opts.CreateDirs); err != nil {
+		return err
+	}
+
+	groups := make(map[string]plotter.Values)
+	order := make(map[string]int)
+	for _, r := range results.GAResults {
+		if r.TaskName != "function_optimization" || len(r.RunFitnessValues) == 0 {
+			continue
+		}
+
+		var key string
+		switch groupBy {
+		case BoxPlotByCrossover:
+			key = r.Config.CrossoverType
+		case BoxPlotByPopulation:
+			key = strconv.Itoa(r.Config.PopulationSize)
+			order[key] = r.Config.PopulationSize
+		default:
+			return fmt.Errorf("неизвестная группировка %q", groupBy)
+		}
+		groups[key] = append(groups[key], r.RunFitnessValues...)
+	}
+	if len(groups) == 0 {
+		return fmt.Errorf("в результатах нет приспособленности отдельных повторов")
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if order[keys[i]] != order[keys[j]] {
+			return order[keys[i]] < order[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	p := plot.New()
+	p.Title.Text = "РАЗБРОС ПРИСПОСОБЛЕННОСТИ ПО ПОВТОРАМ\nОптимизация функции: медиана, квартили и выбросы итоговых значений"
+	p.Title.TextStyle.Font.Size = 16
+	p.Y.Label.Text = "Лучшая приспособленность повтора"
+	p.Y.Label.TextStyle.Font.Size = 14
+	p.X.Label.TextStyle.Font.Size = 14
+	if groupBy == BoxPlotByCrossover {
+		p.X.Label.Text = "Тип скрещивания"
+	} else {
+		p.X.Label.Text = "Размер популяции"
+	}
+
+	colors := []color.RGBA{
+		{R: 255, G: 0, B: 0, A: 255},
+		{R: 0, G: 128, B: 0, A: 255},
+		{R: 0, G: 0, B: 255, A: 255},
+		{R: 255, G: 165, B: 0, A: 255},
+		{R: 128, G: 0, B: 128, A: 255},
+	}
+
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		box, err := plotter.NewBoxPlot(vg.Points(40), float64(i), groups[key])
+		if err != nil {
+			return err
+		}
+		box.FillColor = colors[i%len(colors)]
+		p.Add(box)
+
+		names[i] = key
+		if groupBy == BoxPlotByCrossover {
+			names[i] = crossoverName(key)
+		}
+	}
+
+	p.NominalX(names...)
+	p.X.Min = -0.5
+	p.X.Max = float64(len(keys)) - 0.5
+	p.Add(plotter.NewGrid())
+
+	return savePlot(p, outputFile, 12*vg.Inch, 8*vg.Inch)
+}
+
+// Подпись типа скрещивания; пустой тип — униформное (по умолчанию в ga),
+// неизвестный подписывается как есть.
+func crossoverName(crossoverType string) string {
+	switch crossoverType {
+	case "", "uniform":
+		return "униформное"
+	case "onepoint":
+		return "одноточечное"
+	case "twopoint":
+		return "двухточечное"
+	case "arithmetic":
+		return "арифметическое"
+	case "blend":
+		return "смешанное (BLX)"
+	case "cutsplice":
+		return "cut-and-splice"
+	default:
+		return crossoverType
+	}
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCrossoverName(t *testing.T) {
+	tests := map[string]string{
+		"":           "униформное",
+		"uniform":    "униформное",
+		"onepoint":   "одноточечное",
+		"twopoint":   "двухточечное",
+		"arithmetic": "арифметическое",
+		"blend":      "смешанное (BLX)",
+		"cutsplice":  "cut-and-splice",
+		"threepoint": "threepoint",
+	}
+	for crossoverType, want := range tests {
+		if got := crossoverName(crossoverType); got != want {
+			t.Errorf("crossoverName(%q) = %q, ожидалось %q", crossoverType, got, want)
+		}
+	}
+}
+
+// Ящики подписываются по типу скрещивания, неизвестный тип — как есть.
+func TestRenderFitnessBoxPlotLabels(t *testing.T) {
+	results := &AllResults{}
+	for i, crossoverType := range []string{"uniform", "blend", "cutsplice", "threepoint"} {
+		results.GAResults = append(results.GAResults, ExperimentResult{
+			TaskName:         "function_optimization",
+			Config:           ExperimentConfig{PopulationSize: 10, CrossoverType: crossoverType},
+			RunFitnessValues: []float64{float64(i), float64(i) + 1, float64(i) + 2},
+		})
+	}
+
+	file := filepath.Join(t.TempDir(), "box.svg")
+	if err := RenderFitnessBoxPlot(results, file, BoxPlotByCrossover, PlotOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	svg := string(data)
+	for _, label := range []string{"униформное", "смешанное (BLX)", "cut-and-splice", "threepoint"} {
+		if !strings.Contains(svg, label) {
+			t.Errorf("на диаграмме нет подписи %q", label)
+		}
+	}
+	if strings.Contains(svg, "одноточечное") {
+		t.Error("на диаграмме есть одноточечное скрещивание, которого нет в результатах")
+	}
+
+	if err := RenderFitnessBoxPlot(&AllResults{}, file, BoxPlotByCrossover, PlotOptions{}); err == nil {
+		t.Error("нет ошибки для результатов без повторов")
+	}
+}
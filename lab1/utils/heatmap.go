@@ -0,0 +1,202 @@
+package utils
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette/moreland"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Параметры сетки, доступные для осей тепловой карты. Имена принимаются
+// как в ExperimentConfig (PopulationSize) или как в JSON (population_size).
+var heatmapParams = map[string]func(ExperimentConfig) string{
+	"populationsize": func(c ExperimentConfig) string { return strconv.Itoa(c.PopulationSize) },
+	"maxgenerations": func(c ExperimentConfig) string { return strconv.Itoa(c.MaxGenerations) },
+	"crossoverprob":  func(c ExperimentConfig) string { return strconv.FormatFloat(c.CrossoverProb, 'g', -1, 64) },
+	"mutationprob":   func(c ExperimentConfig) string { return strconv.FormatFloat(c.MutationProb, 'g', -1, 64) },
+	"crossovertype":  func(c ExperimentConfig) string { return c.CrossoverType },
+	"elitismcount":   func(c ExperimentConfig) string { return strconv.Itoa(c.ElitismCount) },
+}
+
+var heatmapParamLabels = map[string]string{
+	"populationsize": "Размер популяции",
+	"maxgenerations": "Число поколений",
+	"crossoverprob":  "Вероятность скрещивания",
+	"mutationprob":   "Вероятность мутации",
+	"crossovertype":  "Тип скрещивания",
+	"elitismcount":   "Размер элиты",
+}
+
+func heatmapParam(name string) (string, error) {
+	key := strings.ToLower(strings.ReplaceAll(name, "_", ""))
+	if _, ok := heatmapParams[key]; !ok {
+		return "", fmt.Errorf("неизвестный параметр %q: ожидается один из PopulationSize, MaxGenerations, CrossoverProb, MutationProb, CrossoverType, ElitismCount", name)
+	}
+	return key, nil
+}
+
+func GenerateParameterHeatmap(resultsFile, outputFile, xParam, yParam string) error {
+	results, err := LoadResults(resultsFile)
+	if err != nil {
+		return err
+	}
+	return RenderParameterHeatmap(results, outputFile, xParam, yParam, PlotOptions{})
+}
+
+// Тепловая карта средней лучшей приспособленности задачи оптимизации
+// функции по двум параметрам сетки; по остальным параметрам значения
+// усредняются. Пустые сочетания закрашиваются серым.
+func RenderParameterHeatmap(results *AllResults, outputFile, xParam, yParam string, opts PlotOptions) error {
+	xKey, err := heatmapParam(xParam)
+	if err != nil {
+		return err
+	}
+	yKey, err := heatmapParam(yParam)
+	if err != nil {
+		return err
+	}
+	if err := ensureOutputDir(outputFile, opts.CreateDirs); err != nil {
+		return err
+	}
+
+	xLabels, yLabels, z, err := pivotHeatmap(results, xKey, yKey)
+	if err != nil {
+		return err
+	}
+	grid := &heatmapGrid{z: z}
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, row := range z {
+		for _, v := range row {
+			if !math.IsNaN(v) {
+				min, max = math.Min(min, v), math.Max(max, v)
+			}
+		}
+	}
+	if min == max {
+		min, max = min-0.5, max+0.5
+	}
+
+	pal := moreland.SmoothBlueRed().Palette(64)
+	heat := plotter.NewHeatMap(grid, pal)
+	heat.Min, heat.Max = min, max
+	heat.NaN = color.RGBA{R: 200, G: 200, B: 200, A: 255}
+
+	p := plot.New()
+	p.Title.Text = "СРЕДНЯЯ ЛУЧШАЯ ПРИСПОСОБЛЕННОСТЬ ПО ПАРАМЕТРАМ\nОптимизация функции: усреднение по остальным параметрам сетки"
+	p.Title.TextStyle.Font.Size = 16
+	p.X.Label.Text = heatmapParamLabels[xKey]
+	p.X.Label.TextStyle.Font.Size = 14
+	p.Y.Label.Text = heatmapParamLabels[yKey]
+	p.Y.Label.TextStyle.Font.Size = 14
+	p.Add(heat)
+	p.NominalX(xLabels...)
+	p.NominalY(yLabels...)
+
+	// Шкала цветов — в легенде, от максимума к минимуму; под неё справа
+	// оставлен пустой столбец.
+	p.X.Min = -0.5
+	p.X.Max = float64(len(xLabels)) + 0.5
+	colors := pal.Colors()
+	const steps = 5
+	for i := steps - 1; i >= 0; i-- {
+		v := min + (max-min)*float64(i)/float64(steps-1)
+		index := int(float64(len(colors)-1) * float64(i) / float64(steps-1))
+		p.Legend.Add(fmt.Sprintf("%.6f", v), colorSwatch{colors[index]})
+	}
+	p.Legend.Top = true
+
+	return savePlot(p, outputFile, 12*vg.Inch, 8*vg.Inch)
+}
+
+// Средняя лучшая приспособленность задачи оптимизации функции в каждом
+// сочетании значений параметров xKey и yKey: z[строка][столбец] для
+// значений yLabels и xLabels; пустые сочетания — NaN.
+func pivotHeatmap(results *AllResults, xKey, yKey string) (xLabels, yLabels []string, z [][]float64, err error) {
+	type cell struct{ x, y string }
+	sums := make(map[cell]float64)
+	counts := make(map[cell]int)
+	var configs []ExperimentConfig
+	for _, r := range results.GAResults {
+		if r.TaskName != "function_optimization" {
+			continue
+		}
+		c := cell{heatmapParams[xKey](r.Config), heatmapParams[yKey](r.Config)}
+		sums[c] += r.BestFitness
+		counts[c]++
+		configs = append(configs, r.Config)
+	}
+	if len(counts) == 0 {
+		return nil, nil, nil, fmt.Errorf("в результатах нет конфигураций задачи оптимизации функции")
+	}
+
+	xLabels = heatmapAxis(configs, xKey)
+	yLabels = heatmapAxis(configs, yKey)
+	z = make([][]float64, len(yLabels))
+	for row, y := range yLabels {
+		z[row] = make([]float64, len(xLabels))
+		for col, x := range xLabels {
+			c := cell{x, y}
+			if counts[c] == 0 {
+				z[row][col] = math.NaN()
+				continue
+			}
+			z[row][col] = sums[c] / float64(counts[c])
+		}
+	}
+	return xLabels, yLabels, z, nil
+}
+
+// Значения параметра по возрастанию: числовые — как числа.
+func heatmapAxis(configs []ExperimentConfig, key string) []string {
+	seen := make(map[string]bool)
+	var labels []string
+	for _, c := range configs {
+		label := heatmapParams[key](c)
+		if !seen[label] {
+			seen[label] = true
+			labels = append(labels, label)
+		}
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		a, errA := strconv.ParseFloat(labels[i], 64)
+		b, errB := strconv.ParseFloat(labels[j], 64)
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		return labels[i] < labels[j]
+	})
+	return labels
+}
+
+// Сетка для plotter.HeatMap: столбцы и строки — индексы значений параметров.
+type heatmapGrid struct {
+	z [][]float64
+}
+
+func (g *heatmapGrid) Dims() (c, r int)   { return len(g.z[0]), len(g.z) }
+func (g *heatmapGrid) Z(c, r int) float64 { return g.z[r][c] }
+func (g *heatmapGrid) X(c int) float64    { return float64(c) }
+func (g *heatmapGrid) Y(r int) float64    { return float64(r) }
+
+// Образец цвета для легенды.
+type colorSwatch struct {
+	color color.Color
+}
+
+func (s colorSwatch) Thumbnail(c *draw.Canvas) {
+	pts := []vg.Point{
+		{X: c.Min.X, Y: c.Min.Y},
+		{X: c.Min.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Min.Y},
+	}
+	c.FillPolygon(s.color, c.ClipPolygonY(pts))
+}
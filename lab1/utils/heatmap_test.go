@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// Размер популяции 10 и 20 против вероятности мутации 0.01 и 0.1; по типу
+// скрещивания значения усредняются, сочетания 20 × 0.1 нет.
+func heatmapResults() *AllResults {
+	results := &AllResults{}
+	add := func(task string, pop int, pm float64, crossover string, best float64) {
+		results.GAResults = append(results.GAResults, ExperimentResult{
+			TaskName: task,
+			Config: ExperimentConfig{PopulationSize: pop, MaxGenerations: 5, CrossoverProb: 0.8,
+				MutationProb: pm, CrossoverType: crossover, ElitismCount: 1},
+			BestFitness: best,
+		})
+	}
+	add("function_optimization", 20, 0.01, "onepoint", 0.8)
+	add("function_optimization", 10, 0.01, "onepoint", 0.5)
+	add("function_optimization", 10, 0.01, "uniform", 0.7)
+	add("function_optimization", 10, 0.1, "onepoint", 0.2)
+	add("function_optimization", 20, 0.01, "uniform", 0.6)
+	add("array_search", 20, 0.1, "uniform", 100) // другая задача не учитывается
+	return results
+}
+
+func TestPivotHeatmapPopulationSizeVsMutationProb(t *testing.T) {
+	xLabels, yLabels, z, err := pivotHeatmap(heatmapResults(), "populationsize", "mutationprob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(xLabels, []string{"10", "20"}) || !slices.Equal(yLabels, []string{"0.01", "0.1"}) {
+		t.Fatalf("оси %v и %v, ожидалось [10 20] и [0.01 0.1]", xLabels, yLabels)
+	}
+	want := [][]float64{{0.6, 0.7}, {0.2, math.NaN()}}
+	for row := range want {
+		for col := range want[row] {
+			got := z[row][col]
+			if math.IsNaN(want[row][col]) != math.IsNaN(got) || math.Abs(got-want[row][col]) > 1e-12 {
+				t.Fatalf("ячейка (%s, %s) = %v, ожидалось %v", xLabels[col], yLabels[row], got, want[row][col])
+			}
+		}
+	}
+}
+
+func TestGenerateParameterHeatmap(t *testing.T) {
+	resultsFile := writeResultsFile(t, heatmapResults())
+	dir := t.TempDir()
+
+	// Имена параметров — как в ExperimentConfig или как в JSON.
+	for _, params := range [][2]string{{"PopulationSize", "MutationProb"}, {"population_size", "mutation_prob"}} {
+		out := filepath.Join(dir, params[0]+".png")
+		if err := GenerateParameterHeatmap(resultsFile, out, params[0], params[1]); err != nil {
+			t.Fatalf("%v: %v", params, err)
+		}
+		data, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.HasPrefix(data, []byte("\x89PNG")) {
+			t.Fatalf("%v: файл не является PNG", params)
+		}
+	}
+
+	unknown := []struct{ x, y, name string }{
+		{"PopulationSize", "Temperature", "Temperature"},
+		{"Seed", "MutationProb", "Seed"},
+	}
+	for _, tc := range unknown {
+		out := filepath.Join(dir, "unknown.png")
+		err := GenerateParameterHeatmap(resultsFile, out, tc.x, tc.y)
+		if err == nil || !strings.Contains(err.Error(), tc.name) || !strings.Contains(err.Error(), "PopulationSize") {
+			t.Fatalf("%s × %s: ошибка %v, ожидалось упоминание %q и списка допустимых параметров", tc.x, tc.y, err, tc.name)
+		}
+		if _, statErr := os.Stat(out); !os.IsNotExist(statErr) {
+			t.Fatalf("%s × %s: при ошибке создан файл", tc.x, tc.y)
+		}
+	}
+
+	arrayOnly := &AllResults{GAResults: heatmapResults().GAResults[5:]}
+	if err := GenerateParameterHeatmap(writeResultsFile(t, arrayOnly), filepath.Join(dir, "empty.png"), "PopulationSize", "MutationProb"); err == nil {
+		t.Fatal("без результатов задачи оптимизации функции ожидалась ошибка")
+	}
+}
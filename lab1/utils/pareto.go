@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Диаграмма фронта Парето многокритериального режима (ga.RunMultiObjective):
+// каждая точка — значения первых двух целей особи. Обе цели считаются
+// минимизируемыми, как у Парето-фронта ошибка/время; область, доминируемая
+// фронтом (выше и правее ступенчатой границы), закрашивается. Пустой фронт
+// даёт пустой график с пояснением в заголовке.
+func GenerateParetoFrontPlot(results [][]float64, outputFile string, opts PlotOptions) error {
+	if err := ensureOutputDir(outputFile, opts.CreateDirs); err != nil {
+		return err
+	}
+
+	points := make(plotter.XYs, 0, len(results))
+	for i, objectives := range results {
+		if len(objectives) < 2 {
+			return fmt.Errorf("точка %d фронта: нужно не меньше двух целей, получено %d", i, len(objectives))
+		}
+		points = append(points, plotter.XY{X: objectives[0], Y: objectives[1]})
+	}
+	sort.SliceStable(points, func(i, j int) bool {
+		if points[i].X != points[j].X {
+			return points[i].X < points[j].X
+		}
+		return points[i].Y < points[j].Y
+	})
+
+	p := plot.New()
+	p.Title.Text = "ФРОНТ ПАРЕТО"
+	p.Title.TextStyle.Font.Size = 16
+	p.X.Label.Text = "Цель 1"
+	p.X.Label.TextStyle.Font.Size = 14
+	p.Y.Label.Text = "Цель 2"
+	p.Y.Label.TextStyle.Font.Size = 14
+	p.Add(plotter.NewGrid())
+
+	if len(points) == 0 {
+		p.Title.Text = "ФРОНТ ПАРЕТО\nФронт пуст: нет недоминируемых решений"
+		return savePlot(p, outputFile, 12*vg.Inch, 8*vg.Inch)
+	}
+
+	dominated, err := plotter.NewPolygon(dominatedRegion(points))
+	if err != nil {
+		return err
+	}
+	dominated.Color = color.RGBA{R: 255, G: 0, B: 0, A: 30}
+	dominated.LineStyle.Color = color.RGBA{R: 200, G: 0, B: 0, A: 255}
+	dominated.LineStyle.Width = vg.Points(1)
+	p.Add(dominated)
+	p.Legend.Add("Доминируемая область", dominated)
+
+	scatter, err := plotter.NewScatter(points)
+	if err != nil {
+		return err
+	}
+	scatter.GlyphStyle.Color = color.RGBA{R: 0, G: 0, B: 255, A: 220}
+	scatter.GlyphStyle.Radius = vg.Points(4)
+	scatter.GlyphStyle.Shape = draw.CircleGlyph{}
+	p.Add(scatter)
+	p.Legend.Add(fmt.Sprintf("Решения фронта (%d)", len(points)), scatter)
+
+	p.Legend.Top = true
+	p.Legend.TextStyle.Font.Size = 10
+
+	return savePlot(p, outputFile, 12*vg.Inch, 8*vg.Inch)
+}
+
+// Многоугольник доминируемой области для точек, упорядоченных по первой
+// цели: ступенчатая граница по текущему минимуму второй цели, замкнутая
+// через правый верхний угол с отступом в 10% от размаха.
+func dominatedRegion(points plotter.XYs) plotter.XYs {
+	xMin, xMax := points[0].X, points[len(points)-1].X
+	yMin, yMax := math.Inf(1), math.Inf(-1)
+	for _, pt := range points {
+		yMin = math.Min(yMin, pt.Y)
+		yMax = math.Max(yMax, pt.Y)
+	}
+	xMax += math.Max((xMax-xMin)*0.1, 1e-3)
+	yMax += math.Max((yMax-yMin)*0.1, 1e-3)
+
+	region := plotter.XYs{{X: points[0].X, Y: yMax}}
+	best := math.Inf(1)
+	for _, pt := range points {
+		if pt.Y >= best {
+			continue
+		}
+		if !math.IsInf(best, 1) {
+			region = append(region, plotter.XY{X: pt.X, Y: best})
+		}
+		region = append(region, pt)
+		best = pt.Y
+	}
+	region = append(region, plotter.XY{X: xMax, Y: best}, plotter.XY{X: xMax, Y: yMax})
+	return region
+}
@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+// Выпуклый фронт y = (1 - sqrt(x))², заданный не по порядку.
+var convexFront = [][]float64{{0.25, 0.25}, {0, 1}, {1, 0}, {0.0625, 0.5625}, {0.5625, 0.0625}}
+
+// Центры кружков радиуса 4 в SVG (точки фронта и значок легенды). Система
+// координат SVG от vgsvg перевёрнута: y растёт вверх, как на графике.
+var svgCircle = regexp.MustCompile(`<path d="M([0-9.]+),([0-9.]+)A4,4 `)
+
+func TestGenerateParetoFrontPlotConvexFront(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "pareto.svg")
+	if err := GenerateParetoFrontPlot(convexFront, out, PlotOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	matches := svgCircle.FindAllStringSubmatch(string(data), -1)
+	if len(matches) < len(convexFront) {
+		t.Fatalf("нарисовано %d точек, ожидалось %d", len(matches), len(convexFront))
+	}
+
+	// Точки рисуются по возрастанию первой цели; положение на холсте
+	// линейно зависит от значений целей: (0, 1) — слева вверху, (1, 0) —
+	// справа внизу.
+	want := [][]float64{{0, 1}, {0.0625, 0.5625}, {0.25, 0.25}, {0.5625, 0.0625}, {1, 0}}
+	centers := make([][2]float64, len(want))
+	for i := range want {
+		x, _ := strconv.ParseFloat(matches[i][1], 64)
+		y, _ := strconv.ParseFloat(matches[i][2], 64)
+		centers[i] = [2]float64{x - 4, y} // путь начинается с правого края кружка
+	}
+	first, last := centers[0], centers[len(centers)-1]
+	if first[0] >= last[0] || first[1] <= last[1] {
+		t.Fatalf("точка (0, 1) нарисована в %v, (1, 0) — в %v", first, last)
+	}
+	for i, point := range want {
+		x := first[0] + point[0]*(last[0]-first[0])
+		y := last[1] + point[1]*(first[1]-last[1])
+		if math.Abs(centers[i][0]-x) > 0.01 || math.Abs(centers[i][1]-y) > 0.01 {
+			t.Fatalf("точка %v нарисована в %v, ожидалось (%.3f, %.3f)", point, centers[i], x, y)
+		}
+	}
+}
+
+// Доминируемая область лежит правее и выше фронта: каждая её вершина
+// не лучше хотя бы одной точки фронта по обеим целям.
+func TestDominatedRegionIsAboveRightOfFront(t *testing.T) {
+	points := plotter.XYs{{X: 0, Y: 1}, {X: 0.0625, Y: 0.5625}, {X: 0.25, Y: 0.25}, {X: 0.5625, Y: 0.0625}, {X: 1, Y: 0}}
+	region := dominatedRegion(points)
+	for _, v := range region {
+		dominated := false
+		for _, p := range points {
+			if v.X >= p.X && v.Y >= p.Y {
+				dominated = true
+			}
+		}
+		if !dominated {
+			t.Fatalf("вершина %v доминируемой области не доминируется фронтом", v)
+		}
+	}
+	if corner := region[len(region)-1]; corner.X <= 1 || corner.Y <= 1 {
+		t.Fatalf("область замыкается в %v, ожидался угол правее и выше фронта", corner)
+	}
+}
+
+func TestGenerateParetoFrontPlotEmptyFront(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "empty.png")
+	if err := GenerateParetoFrontPlot(nil, out, PlotOptions{}); err != nil {
+		t.Fatalf("пустой фронт: %v", err)
+	}
+	if info, err := os.Stat(out); err != nil || info.Size() == 0 {
+		t.Fatalf("для пустого фронта не создан файл: %v", err)
+	}
+	if err := GenerateParetoFrontPlot([][]float64{{1}}, out, PlotOptions{}); err == nil {
+		t.Fatal("для точки с одной целью ожидалась ошибка")
+	}
+}
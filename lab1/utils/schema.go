@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"fmt"
+	"image/color"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// График наблюдаемой и предсказанной теоремой о схемах доли схемы по
+// поколениям (данные — из ga.TraceSchema).
+func RenderSchemaPlot(observed, predicted []float64, schema, outputFile string, opts PlotOptions) error {
+	if err := ensureOutputDir(outputFile, opts.CreateDirs); err != nil {
+		return err
+	}
+	if len(observed) == 0 {
+		return fmt.Errorf("нет данных о частоте схемы")
+	}
+
+	p := plot.New()
+	p.Title.Text = fmt.Sprintf("РАСПРОСТРАНЕНИЕ СТРОИТЕЛЬНОГО БЛОКА\nСхема %s: наблюдение и теорема о схемах", schema)
+	p.Title.TextStyle.Font.Size = 16
+	p.X.Label.Text = "Номер поколения"
+	p.X.Label.TextStyle.Font.Size = 14
+	p.Y.Label.Text = "Доля особей со схемой"
+	p.Y.Label.TextStyle.Font.Size = 14
+	p.Y.Min = 0
+	p.Y.Max = 1
+
+	series := []struct {
+		label  string
+		values []float64
+		color  color.RGBA
+		dashed bool
+	}{
+		{"Наблюдаемая доля", observed, color.RGBA{R: 0, G: 0, B: 255, A: 255}, false},
+		{"Прогноз теоремы о схемах", predicted, color.RGBA{R: 255, G: 0, B: 0, A: 255}, true},
+	}
+
+	for _, s := range series {
+		pts := make(plotter.XYs, len(s.values))
+		for j, val := range s.values {
+			pts[j].X = float64(j)
+			pts[j].Y = val
+		}
+
+		line, err := plotter.NewLine(pts)
+		if err != nil {
+			return err
+		}
+		line.Color = s.color
+		line.Width = vg.Points(2)
+		if s.dashed {
+			line.Dashes = []vg.Length{vg.Points(6), vg.Points(4)}
+		}
+
+		p.Add(line)
+		p.Legend.Add(s.label, line)
+	}
+
+	p.Add(plotter.NewGrid())
+
+	return savePlot(p, outputFile, 14*vg.Inch, 10*vg.Inch)
+}
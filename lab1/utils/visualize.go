@@ -1,27 +1,55 @@
 package utils
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"image/color"
+	"math"
 	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 
 	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/font"
+	"gonum.org/v1/plot/font/liberation"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
 )
 
 type ExperimentResult struct {
-	TaskName      string           `json:"task_name"`
-	Config        ExperimentConfig `json:"config"`
-	BestFitness   float64          `json:"best_fitness"`
-	MeanFitness   float64          `json:"mean_fitness"`
-	StdDevFitness float64          `json:"std_dev_fitness"`
-	ExecutionTime float64          `json:"execution_time_ms"`
-	AbsoluteError float64          `json:"absolute_error"`
-	RelativeError float64          `json:"relative_error"`
-	Convergence   []float64        `json:"convergence"`
+	TaskName           string           `json:"task_name"`
+	Config             ExperimentConfig `json:"config"`
+	BestFitness        float64          `json:"best_fitness"`
+	MeanFitness        float64          `json:"mean_fitness"`
+	StdDevFitness      float64          `json:"std_dev_fitness"`
+	ExecutionTime      float64          `json:"execution_time_ms"`
+	AbsoluteError      float64          `json:"absolute_error"`
+	RelativeError      float64          `json:"relative_error"`
+	Convergence        []float64        `json:"convergence"`
+	MeanConvergence    []float64        `json:"mean_convergence"`
+	StdDevConvergence  []float64        `json:"stddev_convergence"`
+	NormalizedFitness  float64          `json:"normalized_fitness"`
+	Seeds              []int64          `json:"seeds"`
+	Repetitions        int              `json:"repetitions"`
+	FailedRuns         int              `json:"failed_runs"`
+	FitnessEvaluations float64          `json:"fitness_evaluations"`
+	RunFitnessValues   []float64        `json:"run_fitness_values"`
+
+	SelectionDifferential []float64 `json:"selection_differential"`
+	SelectionResponse     []float64 `json:"selection_response"`
+	DistinctIndividuals   int       `json:"distinct_individuals"`
+	DistinctPerGeneration []int     `json:"distinct_per_generation"`
+	Entropy               []float64 `json:"entropy"`
+	ConvergenceRate       float64   `json:"convergence_rate"`
+	ConvergenceRateR2     float64   `json:"convergence_rate_r2"`
+	// Самая частая причина остановки среди повторов и разбивка по причинам.
+	TerminationReason string         `json:"termination_reason"`
+	TerminationCounts map[string]int `json:"termination_counts"`
 }
 
 type ExperimentConfig struct {
@@ -34,17 +62,125 @@ type ExperimentConfig struct {
 }
 
 type LinearSearchResult struct {
-	TaskName      string  `json:"task_name"`
-	BestValue     float64 `json:"best_value"`
-	ExecutionTime float64 `json:"execution_time_ms"`
+	TaskName           string  `json:"task_name"`
+	BestValue          float64 `json:"best_value"`
+	WorstValue         float64 `json:"worst_value"`
+	ExecutionTime      float64 `json:"execution_time_ms"`
+	FitnessEvaluations int     `json:"fitness_evaluations"`
+	SampleRatio        float64 `json:"sample_ratio,omitempty"`
+}
+
+type BaselineResult struct {
+	TaskName           string  `json:"task_name"`
+	Method             string  `json:"method"`
+	BestValue          float64 `json:"best_value"`
+	ExecutionTime      float64 `json:"execution_time_ms"`
+	AbsoluteError      float64 `json:"absolute_error"`
+	RelativeError      float64 `json:"relative_error"`
+	FitnessEvaluations int     `json:"fitness_evaluations"`
 }
 
 type AllResults struct {
-	LinearSearchResults []LinearSearchResult `json:"linear_search_results"`
-	GAResults           []ExperimentResult   `json:"ga_results"`
+	LinearSearchResults       []LinearSearchResult `json:"linear_search_results"`
+	GAResults                 []ExperimentResult   `json:"ga_results"`
+	SimulatedAnnealingResults []BaselineResult     `json:"simulated_annealing_results,omitempty"`
+}
+
+var ErrOutputDirMissing = errors.New("каталог для графика не существует")
+
+var ErrUnsupportedFormat = errors.New("неподдерживаемый формат графика")
+
+// Форматы, в которые сохраняются графики (по расширению файла).
+var plotFormats = []string{".png", ".svg", ".pdf"}
+
+// Настройки сохранения графиков, общие для функций Render*.
+type PlotOptions struct {
+	// Создавать недостающие каталоги для outputFile; иначе их отсутствие —
+	// ошибка ErrOutputDirMissing.
+	CreateDirs bool
+}
+
+func ensureOutputDir(outputFile string, createDirs bool) error {
+	dir := filepath.Dir(outputFile)
+	info, err := os.Stat(dir)
+	if err == nil {
+		if !info.IsDir() {
+			return fmt.Errorf("%s: %w", dir, ErrOutputDirMissing)
+		}
+		return nil
+	}
+	if !os.IsNotExist(err) {
+		return err
+	}
+
+	if !createDirs {
+		return fmt.Errorf("%s: %w", dir, ErrOutputDirMissing)
+	}
+	return os.MkdirAll(dir, 0o755)
+}
+
+// Ошибка, после которой график отрисовать невозможно даже с запасным шрифтом.
+type RenderError struct {
+	File string
+	Err  error
+}
+
+func (e *RenderError) Error() string {
+	return fmt.Sprintf("не удалось отрисовать %s: %v", e.File, e.Err)
+}
+
+func (e *RenderError) Unwrap() error {
+	return e.Err
+}
+
+// В урезанных окружениях шрифт по умолчанию может отсутствовать в кэше;
+// тогда подключается встроенный набор Liberation, и поиск шрифта
+// откатывается на его гарнитуру по умолчанию.
+func ensureFonts() {
+	if !font.DefaultCache.Has(plot.DefaultFont) {
+		font.DefaultCache.Add(liberation.Collection())
+	}
+}
+
+// Формат определяется расширением outputFile: .png, .svg или .pdf
+// (без учёта регистра), иначе возвращается ErrUnsupportedFormat. Ошибки
+// отрисовки и кодирования (в том числе паника внутри plot) возвращаются
+// как RenderError, ошибки записи файла — как есть.
+func savePlot(p *plot.Plot, outputFile string, w, h vg.Length) error {
+	ext := strings.ToLower(filepath.Ext(outputFile))
+	if !slices.Contains(plotFormats, ext) {
+		return fmt.Errorf("%w %q для %s: поддерживаются %s", ErrUnsupportedFormat, ext, outputFile, strings.Join(plotFormats, ", "))
+	}
+
+	ensureFonts()
+
+	image, err := renderPlot(p, ext[1:], w, h)
+	if err != nil {
+		return &RenderError{File: outputFile, Err: err}
+	}
+	return os.WriteFile(outputFile, image, 0o644)
 }
 
-func loadResults(filename string) (*AllResults, error) {
+// Отрисовывает график в память; паника при отрисовке становится ошибкой.
+func renderPlot(p *plot.Plot, format string, w, h vg.Length) (image []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	canvas, err := p.WriterTo(w, h, format)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if _, err := canvas.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func LoadResults(filename string) (*AllResults, error) {
 	file, err := os.Open(filename)
 	if err != nil {
 		return nil, err
@@ -53,19 +189,59 @@ func loadResults(filename string) (*AllResults, error) {
 
 	var results AllResults
 	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
 	err = decoder.Decode(&results)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	if err := validateResults(&results); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
 	}
 
 	return &results, nil
 }
 
+func validateResults(results *AllResults) error {
+	for i, r := range results.LinearSearchResults {
+		if r.TaskName == "" {
+			return fmt.Errorf("linear_search_results[%d]: не задан task_name", i)
+		}
+	}
+
+	for i, r := range results.SimulatedAnnealingResults {
+		if r.TaskName == "" {
+			return fmt.Errorf("simulated_annealing_results[%d]: не задан task_name", i)
+		}
+	}
+
+	for i, r := range results.GAResults {
+		if r.TaskName == "" {
+			return fmt.Errorf("ga_results[%d]: не задан task_name", i)
+		}
+		if r.Config == (ExperimentConfig{}) {
+			return fmt.Errorf("ga_results[%d]: отсутствует config", i)
+		}
+		if r.Config.PopulationSize <= 0 || r.Config.MaxGenerations <= 0 || r.Config.CrossoverType == "" {
+			return fmt.Errorf("ga_results[%d]: неполный config (population_size, max_generations, crossover_type)", i)
+		}
+	}
+
+	return nil
+}
+
 func GenerateTimeComparisonPlot(resultsFile, outputFile string) error {
-	results, err := loadResults(resultsFile)
+	results, err := LoadResults(resultsFile)
 	if err != nil {
 		return err
 	}
+	return RenderTimeComparisonPlot(results, outputFile, PlotOptions{})
+}
+
+func RenderTimeComparisonPlot(results *AllResults, outputFile string, opts PlotOptions) error {
+	if err := ensureOutputDir(outputFile, opts.CreateDirs); err != nil {
+		return err
+	}
 
 	p := plot.New()
 	p.Title.Text = "⚡ СРАВНЕНИЕ ВРЕМЕНИ ВЫПОЛНЕНИЯ ⚡"
@@ -106,6 +282,16 @@ func GenerateTimeComparisonPlot(resultsFile, outputFile string) error {
 	w := vg.Points(30)
 
 	values := plotter.Values{avgArrayGA, arrayLinearTime, avgFuncGA, funcLinearTime}
+	names := []string{"Генетический\nалгоритм\n(поиск в массиве)",
+		"Линейный поиск\n(поиск в массиве)",
+		"Генетический\nалгоритм\n(оптимизация функции)",
+		"Линейный поиск\n(оптимизация функции)"}
+
+	// Имитация отжига — только если она запускалась.
+	for _, r := range results.SimulatedAnnealingResults {
+		values = append(values, r.ExecutionTime)
+		names = append(names, "Имитация отжига\n("+taskDescription(r.TaskName)+")")
+	}
 
 	bars, err := plotter.NewBarChart(values, w)
 	if err != nil {
@@ -120,7 +306,7 @@ func GenerateTimeComparisonPlot(resultsFile, outputFile string) error {
 	}
 
 	for i := 0; i < len(values); i++ {
-		bars.Color = colors[i]
+		bars.Color = colors[i%len(colors)]
 	}
 
 	bars.LineStyle.Width = vg.Length(2)
@@ -128,53 +314,60 @@ func GenerateTimeComparisonPlot(resultsFile, outputFile string) error {
 
 	p.Add(bars)
 
-	if avgArrayGA < 0.001 {
-		avgArrayGA = 0.001
-	}
-	if avgFuncGA < 0.001 {
-		avgFuncGA = 0.001
-	}
-
-	acceleration1 := arrayLinearTime / avgArrayGA
-	acceleration2 := funcLinearTime / avgFuncGA
-
-	var interpretation1, interpretation2 string
-	if acceleration1 > 1 {
-		interpretation1 = fmt.Sprintf("быстрее в %.1fx раз", acceleration1)
-	} else if acceleration1 > 0.1 {
-		interpretation1 = fmt.Sprintf("медленнее в %.1fx раз", 1/acceleration1)
-	} else {
-		interpretation1 = "значительно медленнее"
-	}
-	if acceleration2 > 1 {
-		interpretation2 = fmt.Sprintf("быстрее в %.1fx раз", acceleration2)
-	} else if acceleration2 > 0.1 {
-		interpretation2 = fmt.Sprintf("медленнее в %.1fx раз", 1/acceleration2)
-	} else {
-		interpretation2 = "значительно медленнее"
-	}
+	interpretation1 := interpretSpeedup(arrayLinearTime, avgArrayGA)
+	interpretation2 := interpretSpeedup(funcLinearTime, avgFuncGA)
 
 	p.Title.Text = fmt.Sprintf("СРАВНЕНИЕ ВРЕМЕНИ ВЫПОЛНЕНИЯ\nГА %s для массива, %s для функции\nРезультат зависит от параметров: малая популяция=быстро, большая=медленно", interpretation1, interpretation2)
 
-	p.NominalX("Генетический\nалгоритм\n(поиск в массиве)",
-		"Линейный поиск\n(поиск в массиве)",
-		"Генетический\nалгоритм\n(оптимизация функции)",
-		"Линейный поиск\n(оптимизация функции)")
+	p.NominalX(names...)
 
 	p.Add(plotter.NewGrid())
 
-	if err := p.Save(12*vg.Inch, 8*vg.Inch, outputFile); err != nil {
-		return err
+	return savePlot(p, outputFile, 12*vg.Inch, 8*vg.Inch)
+}
+
+func interpretSpeedup(linearTime, gaTime float64) string {
+	if gaTime == 0 || linearTime == 0 {
+		return "без данных о времени"
 	}
 
-	return nil
+	acceleration := linearTime / gaTime
+	if acceleration > 1 {
+		return fmt.Sprintf("быстрее в %.1fx раз", acceleration)
+	} else if acceleration > 0.1 {
+		return fmt.Sprintf("медленнее в %.1fx раз", 1/acceleration)
+	}
+	return "значительно медленнее"
+}
+
+// TaskName — задача, кривые которой рисуются (по умолчанию "array_search").
+type ConvergenceFilter struct {
+	Match      func(ExperimentConfig) bool
+	Selection  string
+	MaxConfigs int
+	TaskName   string
 }
 
 func GenerateConvergencePlot(resultsFile, outputFile string) error {
-	results, err := loadResults(resultsFile)
+	return GenerateConvergencePlotFiltered(resultsFile, outputFile, ConvergenceFilter{})
+}
+
+func GenerateFunctionConvergencePlot(resultsFile, outputFile string) error {
+	return GenerateConvergencePlotFiltered(resultsFile, outputFile, ConvergenceFilter{TaskName: "function_optimization"})
+}
+
+func GenerateConvergencePlotFiltered(resultsFile, outputFile string, filter ConvergenceFilter) error {
+	results, err := LoadResults(resultsFile)
 	if err != nil {
 		return err
 	}
+	return RenderConvergencePlot(results, outputFile, filter, PlotOptions{})
+}
+
+func RenderConvergencePlot(results *AllResults, outputFile string, filter ConvergenceFilter, opts PlotOptions) error {
+	if err := ensureOutputDir(outputFile, opts.CreateDirs); err != nil {
+		return err
+	}
 
 	p := plot.New()
 	p.Title.Text = "📈 СХОДИМОСТЬ ГЕНЕТИЧЕСКОГО АЛГОРИТМА 📈"
@@ -188,7 +381,6 @@ func GenerateConvergencePlot(resultsFile, outputFile string) error {
 	p.Legend.TextStyle.Font.Size = 10
 
 	configsToShow := 0
-	maxConfigs := 6
 	colors := []color.RGBA{
 		{R: 255, G: 0, B: 0, A: 255},   // Красный
 		{R: 0, G: 128, B: 0, A: 255},   // Зеленый
@@ -198,15 +390,7 @@ func GenerateConvergencePlot(resultsFile, outputFile string) error {
 		{R: 0, G: 191, B: 255, A: 255}, // Голубой
 	}
 
-	for _, r := range results.GAResults {
-		if r.TaskName != "array_search" || len(r.Convergence) == 0 {
-			continue
-		}
-
-		if configsToShow >= maxConfigs {
-			break
-		}
-
+	for _, r := range selectConvergenceResults(results, filter) {
 		pts := make(plotter.XYs, len(r.Convergence))
 		for j, val := range r.Convergence {
 			pts[j].X = float64(j)
@@ -233,10 +417,7 @@ func GenerateConvergencePlot(resultsFile, outputFile string) error {
 			mutationDesc = "высокая"
 		}
 
-		crossoverDesc := "одноточечное"
-		if r.Config.CrossoverType == "uniform" {
-			crossoverDesc = "униформное"
-		}
+		crossoverDesc := crossoverName(r.Config.CrossoverType)
 
 		label := fmt.Sprintf("%s | %.2f мутация | %s скрещивание | популяция=%d",
 			mutationDesc, r.Config.MutationProb, crossoverDesc, r.Config.PopulationSize)
@@ -249,20 +430,208 @@ func GenerateConvergencePlot(resultsFile, outputFile string) error {
 
 	p.Add(plotter.NewGrid())
 
-	p.Title.Text = "СХОДИМОСТЬ ГЕНЕТИЧЕСКОГО АЛГОРИТМА\nВысокая мутация → больше исследования пространства | Низкая мутация → быстрая сходимость к локальному оптимуму"
+	p.Title.Text = "СХОДИМОСТЬ ГЕНЕТИЧЕСКОГО АЛГОРИТМА (" + taskDescription(filter.TaskName) + ")\nВысокая мутация → больше исследования пространства | Низкая мутация → быстрая сходимость к локальному оптимуму"
+
+	return savePlot(p, outputFile, 14*vg.Inch, 10*vg.Inch)
+}
+
+// Selection: "first" (по умолчанию) — первые MaxConfigs подходящих конфигураций,
+// "extremes" — лучшая, медианная и худшая по итоговой приспособленности.
+func selectConvergenceResults(results *AllResults, filter ConvergenceFilter) []ExperimentResult {
+	maxConfigs := filter.MaxConfigs
+	if maxConfigs <= 0 {
+		maxConfigs = 6
+	}
+
+	taskName := filter.TaskName
+	if taskName == "" {
+		taskName = "array_search"
+	}
 
-	if err := p.Save(14*vg.Inch, 10*vg.Inch, outputFile); err != nil {
+	var matched []ExperimentResult
+	for _, r := range results.GAResults {
+		if r.TaskName != taskName || len(r.Convergence) == 0 {
+			continue
+		}
+		if filter.Match != nil && !filter.Match(r.Config) {
+			continue
+		}
+		matched = append(matched, r)
+	}
+
+	if filter.Selection == "extremes" && len(matched) > 3 {
+		sort.SliceStable(matched, func(i, j int) bool {
+			return matched[i].BestFitness > matched[j].BestFitness
+		})
+		matched = []ExperimentResult{matched[0], matched[len(matched)/2], matched[len(matched)-1]}
+	}
+
+	if len(matched) > maxConfigs {
+		matched = matched[:maxConfigs]
+	}
+
+	return matched
+}
+
+func GenerateSelectionResponsePlot(resultsFile, outputFile string) error {
+	results, err := LoadResults(resultsFile)
+	if err != nil {
 		return err
 	}
+	return RenderSelectionResponsePlot(results, outputFile, PlotOptions{})
+}
 
-	return nil
+func RenderSelectionResponsePlot(results *AllResults, outputFile string, opts PlotOptions) error {
+	if err := ensureOutputDir(outputFile, opts.CreateDirs); err != nil {
+		return err
+	}
+
+	var chosen *ExperimentResult
+	for i, r := range results.GAResults {
+		if r.TaskName == "array_search" && len(r.SelectionDifferential) > 0 {
+			chosen = &results.GAResults[i]
+			break
+		}
+	}
+	if chosen == nil {
+		return fmt.Errorf("в результатах нет данных о дифференциале отбора")
+	}
+
+	p := plot.New()
+	p.Title.Text = fmt.Sprintf("ДИФФЕРЕНЦИАЛ ОТБОРА И ОТВЕТ НА ОТБОР\nУравнение селекционера: R = h² · S | популяция=%d, мутация=%.2f",
+		chosen.Config.PopulationSize, chosen.Config.MutationProb)
+	p.Title.TextStyle.Font.Size = 16
+	p.X.Label.Text = "Номер поколения"
+	p.X.Label.TextStyle.Font.Size = 14
+	p.Y.Label.Text = "Изменение средней приспособленности"
+	p.Y.Label.TextStyle.Font.Size = 14
+
+	series := []struct {
+		label  string
+		values []float64
+		color  color.RGBA
+	}{
+		{"Дифференциал отбора S", chosen.SelectionDifferential, color.RGBA{R: 255, G: 0, B: 0, A: 255}},
+		{"Ответ на отбор R", chosen.SelectionResponse, color.RGBA{R: 0, G: 0, B: 255, A: 255}},
+	}
+
+	for _, s := range series {
+		pts := make(plotter.XYs, len(s.values))
+		for j, val := range s.values {
+			pts[j].X = float64(j)
+			pts[j].Y = val
+		}
+
+		line, err := plotter.NewLine(pts)
+		if err != nil {
+			return err
+		}
+		line.Color = s.color
+		line.Width = vg.Points(2)
+
+		p.Add(line)
+		p.Legend.Add(s.label, line)
+	}
+
+	p.Add(plotter.NewGrid())
+
+	return savePlot(p, outputFile, 14*vg.Inch, 10*vg.Inch)
+}
+
+func GenerateEntropyPlot(resultsFile, outputFile string) error {
+	results, err := LoadResults(resultsFile)
+	if err != nil {
+		return err
+	}
+	return RenderEntropyPlot(results, outputFile, PlotOptions{})
+}
+
+// Средняя по конфигурациям задачи поиска в массиве энтропия популяции
+// по поколениям, отдельная линия для каждой вероятности мутации.
+func RenderEntropyPlot(results *AllResults, outputFile string, opts PlotOptions) error {
+	if err := ensureOutputDir(outputFile, opts.CreateDirs); err != nil {
+		return err
+	}
+
+	sums := make(map[float64][]float64)
+	counts := make(map[float64][]int)
+	for _, r := range results.GAResults {
+		if r.TaskName != "array_search" || len(r.Entropy) == 0 {
+			continue
+		}
+		rate := r.Config.MutationProb
+		for len(sums[rate]) < len(r.Entropy) {
+			sums[rate] = append(sums[rate], 0)
+			counts[rate] = append(counts[rate], 0)
+		}
+		for j, val := range r.Entropy {
+			sums[rate][j] += val
+			counts[rate][j]++
+		}
+	}
+	if len(sums) == 0 {
+		return fmt.Errorf("в результатах нет данных об энтропии популяции")
+	}
+
+	rates := make([]float64, 0, len(sums))
+	for rate := range sums {
+		rates = append(rates, rate)
+	}
+	sort.Float64s(rates)
+
+	p := plot.New()
+	p.Title.Text = "ЭНТРОПИЯ ПОПУЛЯЦИИ ПО ПОКОЛЕНИЯМ\nСредняя энтропия Шеннона на локус: 1 — полное разнообразие, 0 — все особи совпадают"
+	p.Title.TextStyle.Font.Size = 16
+	p.X.Label.Text = "Номер поколения"
+	p.X.Label.TextStyle.Font.Size = 14
+	p.Y.Label.Text = "Энтропия (бит на локус)"
+	p.Y.Label.TextStyle.Font.Size = 14
+	p.Y.Min = 0
+	p.Y.Max = 1
+
+	colors := []color.RGBA{
+		{R: 255, G: 0, B: 0, A: 255},
+		{R: 0, G: 128, B: 0, A: 255},
+		{R: 0, G: 0, B: 255, A: 255},
+		{R: 255, G: 165, B: 0, A: 255},
+		{R: 128, G: 0, B: 128, A: 255},
+	}
+
+	for i, rate := range rates {
+		pts := make(plotter.XYs, len(sums[rate]))
+		for j := range pts {
+			pts[j].X = float64(j)
+			pts[j].Y = sums[rate][j] / float64(counts[rate][j])
+		}
+
+		line, err := plotter.NewLine(pts)
+		if err != nil {
+			return err
+		}
+		line.Color = colors[i%len(colors)]
+		line.Width = vg.Points(2)
+
+		p.Add(line)
+		p.Legend.Add(fmt.Sprintf("мутация=%.2f", rate), line)
+	}
+
+	p.Add(plotter.NewGrid())
+
+	return savePlot(p, outputFile, 14*vg.Inch, 10*vg.Inch)
 }
 
 func GenerateAccuracyVsTimePlot(resultsFile, outputFile string) error {
-	results, err := loadResults(resultsFile)
+	results, err := LoadResults(resultsFile)
 	if err != nil {
 		return err
 	}
+	return RenderAccuracyVsTimePlot(results, outputFile, PlotOptions{})
+}
+
+func RenderAccuracyVsTimePlot(results *AllResults, outputFile string, opts PlotOptions) error {
+	if err := ensureOutputDir(outputFile, opts.CreateDirs); err != nil {
+		return err
+	}
 
 	p := plot.New()
 	p.Title.Text = "⚖️ КОМПРОМИСС ТОЧНОСТЬ/ВРЕМЯ ⚖️"
@@ -342,6 +711,24 @@ func GenerateAccuracyVsTimePlot(resultsFile, outputFile string) error {
 		p.Legend.Add("Оптимизация функции (математическая)", funcScatter)
 	}
 
+	for _, task := range []string{"array_search", "function_optimization"} {
+		front := paretoFront(results.GAResults, task)
+		if len(front) < 2 {
+			continue
+		}
+		frontLine, err := plotter.NewLine(front)
+		if err != nil {
+			return err
+		}
+		frontLine.LineStyle.Color = color.RGBA{R: 0, G: 0, B: 0, A: 200}
+		frontLine.LineStyle.Width = vg.Points(1.5)
+		frontLine.LineStyle.Dashes = []vg.Length{vg.Points(4), vg.Points(3)}
+		p.Add(frontLine)
+		if task == "array_search" {
+			p.Legend.Add("Парето-фронт (ошибка/время)", frontLine)
+		}
+	}
+
 	excellentLegend, err := plotter.NewPolygon(plotter.XYs{{X: 0, Y: 0}})
 	if err == nil {
 		excellentLegend.Color = color.RGBA{R: 0, G: 255, B: 0, A: 50}
@@ -361,18 +748,69 @@ func GenerateAccuracyVsTimePlot(resultsFile, outputFile string) error {
 
 	p.Title.Text = "КОМПРОМИСС ТОЧНОСТЬ/ВРЕМЯ\nБыстро+точно (идеал) | Быстро+приблизительно (практично) | Медленно+точно (эталон)"
 
-	if err := p.Save(14*vg.Inch, 10*vg.Inch, outputFile); err != nil {
-		return err
+	return savePlot(p, outputFile, 14*vg.Inch, 10*vg.Inch)
+}
+
+// Точки Парето-фронта задачи (модуль относительной ошибки в процентах
+// и время минимизируются), упорядоченные по времени. То же правило
+// доминирования, что в experiment.AllResults.ParetoConfigs.
+func paretoFront(results []ExperimentResult, task string) plotter.XYs {
+	points := make(plotter.XYs, 0)
+	for _, r := range results {
+		if r.TaskName == task {
+			points = append(points, plotter.XY{X: r.ExecutionTime, Y: math.Abs(r.RelativeError) * 100})
+		}
 	}
 
-	return nil
+	front := make(plotter.XYs, 0)
+	for i, candidate := range points {
+		dominated := false
+		for j, other := range points {
+			if i != j && other.X <= candidate.X && other.Y <= candidate.Y &&
+				(other.X < candidate.X || other.Y < candidate.Y) {
+				dominated = true
+				break
+			}
+		}
+		if !dominated {
+			front = append(front, candidate)
+		}
+	}
+
+	sort.Slice(front, func(i, j int) bool {
+		if front[i].X != front[j].X {
+			return front[i].X < front[j].X
+		}
+		return front[i].Y > front[j].Y
+	})
+	return front
 }
 
 func GenerateEfficiencyComparisonPlot(resultsFile, outputFile string) error {
-	results, err := loadResults(resultsFile)
+	results, err := LoadResults(resultsFile)
 	if err != nil {
 		return err
 	}
+	return RenderEfficiencyComparisonPlot(results, outputFile, PlotOptions{})
+}
+
+// Знаменатель индекса эффективности: время в миллисекундах (зависит от
+// машины) или число вычислений приспособленности (не зависит).
+type EfficiencyMetric int
+
+const (
+	EfficiencyPerMs EfficiencyMetric = iota
+	EfficiencyPerEvaluation
+)
+
+func RenderEfficiencyComparisonPlot(results *AllResults, outputFile string, opts PlotOptions) error {
+	return RenderEfficiencyComparisonPlotMetric(results, outputFile, EfficiencyPerMs, opts)
+}
+
+func RenderEfficiencyComparisonPlotMetric(results *AllResults, outputFile string, metric EfficiencyMetric, opts PlotOptions) error {
+	if err := ensureOutputDir(outputFile, opts.CreateDirs); err != nil {
+		return err
+	}
 
 	p := plot.New()
 	p.Title.Text = "🏆 СРАВНЕНИЕ ЭФФЕКТИВНОСТИ АЛГОРИТМОВ 🏆"
@@ -383,12 +821,24 @@ func GenerateEfficiencyComparisonPlot(resultsFile, outputFile string) error {
 	p.Y.Label.Text = "Индекс эффективности (баллы)"
 	p.Y.Label.TextStyle.Font.Size = 14
 
-	arrayGAEff := calculateEfficiency(results, "array_search", true)
-	arrayLinearEff := calculateEfficiency(results, "array_search", false)
-	funcGAEff := calculateEfficiency(results, "function_optimization", true)
-	funcLinearEff := calculateEfficiency(results, "function_optimization", false)
+	arrayGAEff := calculateEfficiency(results, "array_search", true, metric)
+	arrayLinearEff := calculateEfficiency(results, "array_search", false, metric)
+	funcGAEff := calculateEfficiency(results, "function_optimization", true, metric)
+	funcLinearEff := calculateEfficiency(results, "function_optimization", false, metric)
 
 	values := plotter.Values{arrayGAEff, arrayLinearEff, funcGAEff, funcLinearEff}
+	names := []string{"Генетический\nалгоритм\n(поиск в массиве)",
+		"Линейный поиск\n(поиск в массиве)",
+		"Генетический\nалгоритм\n(оптимизация функции)",
+		"Линейный поиск\n(оптимизация функции)"}
+
+	annealing := ""
+	for _, r := range results.SimulatedAnnealingResults {
+		eff := annealingEfficiency(r, metric)
+		values = append(values, eff)
+		names = append(names, "Имитация отжига\n("+taskDescription(r.TaskName)+")")
+		annealing += fmt.Sprintf(" | Отжиг(%s): %.1f баллов", taskDescription(r.TaskName), eff)
+	}
 
 	w := vg.Points(40)
 	bars, err := plotter.NewBarChart(values, w)
@@ -404,7 +854,7 @@ func GenerateEfficiencyComparisonPlot(resultsFile, outputFile string) error {
 	}
 
 	for i := 0; i < len(values); i++ {
-		bars.Color = colors[i]
+		bars.Color = colors[i%len(colors)]
 	}
 
 	bars.LineStyle.Width = vg.Length(3)
@@ -412,30 +862,31 @@ func GenerateEfficiencyComparisonPlot(resultsFile, outputFile string) error {
 
 	p.Add(bars)
 
-	p.Title.Text = fmt.Sprintf("СРАВНЕНИЕ ЭФФЕКТИВНОСТИ АЛГОРИТМОВ\nФормула эффективности: (100 - ошибка%%) / время_мс × 1000\nГА(массив): %.1f баллов | Линейный(массив): %.1f баллов | ГА(функция): %.1f баллов | Линейный(функция): %.1f баллов\nЧем выше балл, тем лучше соотношение точности и скорости",
-		arrayGAEff, arrayLinearEff, funcGAEff, funcLinearEff)
+	formula := "(100 - ошибка%) / время_мс × 1000"
+	if metric == EfficiencyPerEvaluation {
+		formula = "(100 - ошибка%) / вычисления × 1000"
+	}
+	p.Title.Text = fmt.Sprintf("СРАВНЕНИЕ ЭФФЕКТИВНОСТИ АЛГОРИТМОВ\nФормула эффективности: %s\nГА(массив): %.1f баллов | Линейный(массив): %.1f баллов | ГА(функция): %.1f баллов | Линейный(функция): %.1f баллов%s\nЧем выше балл, тем лучше соотношение точности и скорости",
+		formula, arrayGAEff, arrayLinearEff, funcGAEff, funcLinearEff, annealing)
 
-	p.NominalX("Генетический\nалгоритм\n(поиск в массиве)",
-		"Линейный поиск\n(поиск в массиве)",
-		"Генетический\nалгоритм\n(оптимизация функции)",
-		"Линейный поиск\n(оптимизация функции)")
+	p.NominalX(names...)
 
 	p.Add(plotter.NewGrid())
 
-	if err := p.Save(14*vg.Inch, 10*vg.Inch, outputFile); err != nil {
-		return err
-	}
-
-	return nil
+	return savePlot(p, outputFile, 14*vg.Inch, 10*vg.Inch)
 }
 
-func calculateEfficiency(results *AllResults, taskName string, isGA bool) float64 {
+func calculateEfficiency(results *AllResults, taskName string, isGA bool, metric EfficiencyMetric) float64 {
 	if isGA {
 		var totalTime, totalError float64
 		count := 0
 		for _, r := range results.GAResults {
 			if r.TaskName == taskName {
-				totalTime += r.ExecutionTime
+				if metric == EfficiencyPerEvaluation {
+					totalTime += r.FitnessEvaluations
+				} else {
+					totalTime += r.ExecutionTime
+				}
 				totalError += r.RelativeError * 100
 				count++
 			}
@@ -445,33 +896,50 @@ func calculateEfficiency(results *AllResults, taskName string, isGA bool) float6
 		}
 		avgTime := totalTime / float64(count)
 		avgError := totalError / float64(count)
-		if avgTime < 0.001 {
-			avgTime = 0.001
-		}
 		if avgError > 99 {
 			avgError = 99
 		}
 		if avgError < 0.1 {
 			avgError = 0.1
 		}
-		efficiency := (100 - avgError) / avgTime * 1000
-		if efficiency > 100000 {
-			efficiency = 100000
-		}
-		return efficiency
+		return (100 - avgError) / avgTime * 1000
 	} else {
 		for _, r := range results.LinearSearchResults {
 			if r.TaskName == taskName {
-				if r.ExecutionTime == 0 {
+				cost := r.ExecutionTime
+				if metric == EfficiencyPerEvaluation {
+					cost = float64(r.FitnessEvaluations)
+				}
+				if cost == 0 {
 					return 0
 				}
-				return 100 / r.ExecutionTime * 1000
+				return 100 / cost * 1000
 			}
 		}
 	}
 	return 0
 }
 
+// Та же формула, что для ГА в calculateEfficiency.
+func annealingEfficiency(r BaselineResult, metric EfficiencyMetric) float64 {
+	cost := r.ExecutionTime
+	if metric == EfficiencyPerEvaluation {
+		cost = float64(r.FitnessEvaluations)
+	}
+	if cost == 0 {
+		return 0
+	}
+	relError := math.Max(0.1, math.Min(99, r.RelativeError*100))
+	return (100 - relError) / cost * 1000
+}
+
+func taskDescription(taskName string) string {
+	if taskName == "function_optimization" {
+		return "оптимизация функции"
+	}
+	return "поиск в массиве"
+}
+
 func average(values []float64) float64 {
 	if len(values) == 0 {
 		return 0
@@ -0,0 +1,197 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+func sampleResults() *AllResults {
+	results := &AllResults{
+		LinearSearchResults: []LinearSearchResult{
+			{TaskName: "array_search", BestValue: 10, ExecutionTime: 1},
+			{TaskName: "function_optimization", BestValue: 2, ExecutionTime: 1},
+		},
+	}
+	for i, task := range []string{"array_search", "function_optimization"} {
+		results.GAResults = append(results.GAResults, ExperimentResult{
+			TaskName:      task,
+			Config:        ExperimentConfig{PopulationSize: 10, MaxGenerations: 3, CrossoverProb: 0.8, MutationProb: 0.05, CrossoverType: "uniform", ElitismCount: 1},
+			BestFitness:   float64(9 - i),
+			ExecutionTime: 0.5,
+			Convergence:   []float64{5, 7, float64(9 - i)},
+		})
+	}
+	return results
+}
+
+// Сохраняет результаты в JSON во временном каталоге теста.
+func writeResultsFile(t *testing.T, results *AllResults) string {
+	t.Helper()
+	data, err := json.Marshal(results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(t.TempDir(), "results.json")
+	if err := os.WriteFile(file, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+// Режим создания каталогов передаётся в каждый вызов, поэтому
+// одновременные вызовы с разными настройками не влияют друг на друга.
+func TestPlotOptionsCreateDirsPerCall(t *testing.T) {
+	results := sampleResults()
+	root := t.TempDir()
+
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			file := filepath.Join(root, fmt.Sprint(i), "time.png")
+			errs[i] = RenderTimeComparisonPlot(results, file, PlotOptions{CreateDirs: i%2 == 0})
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		file := filepath.Join(root, fmt.Sprint(i), "time.png")
+		if i%2 == 0 {
+			if err != nil {
+				t.Fatalf("CreateDirs: %v", err)
+			}
+			if _, err := os.Stat(file); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		if !errors.Is(err, ErrOutputDirMissing) {
+			t.Fatalf("без CreateDirs ожидалась ErrOutputDirMissing, получено %v", err)
+		}
+	}
+}
+
+type panickingPlotter struct{}
+
+func (panickingPlotter) Plot(draw.Canvas, *plot.Plot) { panic("сбой отрисовки") }
+
+// Сбой отрисовки — RenderError, ошибка записи файла — исходная ошибка ОС.
+func TestSavePlotErrorKinds(t *testing.T) {
+	dir := t.TempDir()
+
+	p := plot.New()
+	p.Add(panickingPlotter{})
+	var renderErr *RenderError
+	if err := savePlot(p, filepath.Join(dir, "panic.png"), 4*vg.Inch, 3*vg.Inch); !errors.As(err, &renderErr) {
+		t.Fatalf("паника отрисовки: ожидалась RenderError, получено %v", err)
+	}
+
+	// Путь занят каталогом: записать файл нельзя, хотя отрисовка удалась.
+	busy := filepath.Join(dir, "busy.png")
+	if err := os.Mkdir(busy, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	err := savePlot(plot.New(), busy, 4*vg.Inch, 3*vg.Inch)
+	var pathErr *fs.PathError
+	if errors.As(err, &renderErr) || !errors.As(err, &pathErr) {
+		t.Fatalf("ошибка записи: ожидалась *fs.PathError без RenderError, получено %T %v", err, err)
+	}
+}
+
+// Векторные форматы: каждый генератор пишет непустой файл с заголовком
+// SVG или PDF; регистр расширения не важен, прочие расширения — ошибка.
+func TestRenderVectorFormats(t *testing.T) {
+	results := sampleResults()
+	renderers := map[string]func(string) error{
+		"time": func(file string) error { return RenderTimeComparisonPlot(results, file, PlotOptions{}) },
+		"convergence": func(file string) error {
+			return RenderConvergencePlot(results, file, ConvergenceFilter{}, PlotOptions{})
+		},
+		"accuracy":   func(file string) error { return RenderAccuracyVsTimePlot(results, file, PlotOptions{}) },
+		"efficiency": func(file string) error { return RenderEfficiencyComparisonPlot(results, file, PlotOptions{}) },
+	}
+	headers := map[string]string{".svg": "<?xml", ".SVG": "<?xml", ".pdf": "%PDF-"}
+
+	dir := t.TempDir()
+	for name, render := range renderers {
+		for ext, header := range headers {
+			file := filepath.Join(dir, name+ext)
+			if err := render(file); err != nil {
+				t.Fatalf("%s%s: %v", name, ext, err)
+			}
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.HasPrefix(data, []byte(header)) {
+				t.Fatalf("%s%s начинается с %q, ожидался заголовок %q", name, ext, data[:min(len(data), 16)], header)
+			}
+			if ext != ".pdf" && !bytes.Contains(data[:min(len(data), 512)], []byte("<svg")) {
+				t.Fatalf("%s%s: нет элемента <svg> в начале файла", name, ext)
+			}
+		}
+
+		if err := render(filepath.Join(dir, name+".bmp")); !errors.Is(err, ErrUnsupportedFormat) {
+			t.Fatalf("%s.bmp: ожидалась ErrUnsupportedFormat, получено %v", name, err)
+		}
+	}
+}
+
+// Кривые задачи оптимизации функции рисуются из файла результатов;
+// конфигурации с пустой Convergence пропускаются, а если кривых нет
+// совсем, график всё равно сохраняется без паники.
+func TestGenerateFunctionConvergencePlot(t *testing.T) {
+	results := &AllResults{}
+	add := func(task string, pm float64, convergence []float64) {
+		results.GAResults = append(results.GAResults, ExperimentResult{
+			TaskName: task,
+			Config: ExperimentConfig{PopulationSize: 20, MaxGenerations: len(convergence) + 1, CrossoverProb: 0.8,
+				MutationProb: pm, CrossoverType: "onepoint", ElitismCount: 1},
+			Convergence: convergence,
+		})
+	}
+	add("array_search", 0.01, []float64{1, 2, 3})
+	add("function_optimization", 0.01, []float64{0.2, 0.5, 0.8, 0.85})
+	add("function_optimization", 0.02, nil)
+	add("function_optimization", 0.1, []float64{0.4, 0.88})
+	add("function_optimization", 0.2, []float64{0.7})
+
+	selected := selectConvergenceResults(results, ConvergenceFilter{TaskName: "function_optimization"})
+	var got []float64
+	for _, r := range selected {
+		got = append(got, r.Config.MutationProb)
+	}
+	if want := []float64{0.01, 0.1, 0.2}; !slices.Equal(got, want) {
+		t.Fatalf("выбраны конфигурации с вероятностью мутации %v, ожидалось %v", got, want)
+	}
+
+	dir := t.TempDir()
+	empty := &AllResults{GAResults: results.GAResults[:3]} // у функции только пустая кривая
+	for name, r := range map[string]*AllResults{"function.png": results, "empty.png": empty} {
+		out := filepath.Join(dir, name)
+		if err := GenerateFunctionConvergencePlot(writeResultsFile(t, r), out); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		data, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.HasPrefix(data, []byte("\x89PNG")) {
+			t.Fatalf("%s: файл не является PNG", name)
+		}
+	}
+}